@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bblfsh/python-driver/driver/batch"
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/parser"
+
+	"gopkg.in/bblfsh/sdk.v1/uast/ann"
+)
+
+// batchCommand is the name of the subcommand parsing a directory.
+const batchCommand = "batch"
+
+// globsFlag is a flag that can be repeated, or given as a comma separated
+// list.
+type globsFlag []string
+
+func (g *globsFlag) String() string { return strings.Join(*g, ",") }
+
+func (g *globsFlag) Set(v string) error {
+	*g = append(*g, strings.Split(v, ",")...)
+	return nil
+}
+
+// runBatch runs the batch subcommand with its arguments, returning the exit
+// code: 1 if some file couldn't be parsed, 2 on other errors.
+func runBatch(args []string, mode normalizer.Mode, rules *ann.Rule) int {
+	fs := flag.NewFlagSet(batchCommand, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s %s [flags] DIR\n\n", os.Args[0], batchCommand)
+		fmt.Fprintln(os.Stderr, "Parses the .py and .pyi files in DIR, skipping the ones ignored by .gitignore.")
+		fs.PrintDefaults()
+	}
+
+	var include, exclude globsFlag
+	fs.Var(&include, "include", "glob of the files to parse, as in .gitignore (default *.py,*.pyi)")
+	fs.Var(&exclude, "exclude", "glob of the files or directories to skip, as in .gitignore")
+	workers := fs.Int("workers", 0, "number of native drivers (default the number of CPUs)")
+	timeout := fs.Duration("timeout", time.Minute, "maximum time to parse a file")
+	out := fs.String("out", "", "directory to write the UAST of each file, as FILE.json")
+	ndjson := fs.String("ndjson", "", "file to write the UASTs as newline delimited JSON, - for stdout")
+	native := fs.String("native", parser.DefaultNative, "command of the native driver")
+	python := fs.String("python", "auto", "version of Python of the files: 2, 3 or auto")
+	cacheDir := fs.String("cache", "", "directory to cache the native ASTs and UASTs between runs")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	version, err := parser.ParsePythonVersion(*python)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	if fs.NArg() != 1 || (*out == "") == (*ndjson == "") {
+		fmt.Fprintln(os.Stderr, "a directory and one of -out or -ndjson are required")
+		fs.Usage()
+		return 2
+	}
+
+	var handle func(*batch.Result) error
+	if *out != "" {
+		handle = batch.DirWriter(*out)
+	} else {
+		var w io.Writer = os.Stdout
+		if *ndjson != "-" {
+			f, err := os.Create(*ndjson)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return 2
+			}
+
+			defer f.Close()
+			w = f
+		}
+
+		handle = batch.NDJSONWriter(w)
+	}
+
+	var cache *parser.Cache
+	if *cacheDir != "" {
+		if cache, err = parser.NewCache(parser.CacheConfig{Dir: *cacheDir}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+
+	pool := parser.NewPool(parser.PoolConfig{Native: *native, Size: *workers, Timeout: *timeout})
+	defer pool.Close()
+
+	s, err := batch.Run(context.Background(), &batch.Config{
+		Root:    fs.Arg(0),
+		Include: include,
+		Exclude: exclude,
+		Workers: *workers,
+		Options: parser.Options{Pool: pool, Mode: mode, Rules: rules, PythonVersion: version, Cache: cache},
+	}, handle)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	for _, r := range s.Failed {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", r.Path, r.Err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d files, %d errors in %s\n", s.Files, len(s.Failed), s.Elapsed)
+	if len(s.Failed) > 0 {
+		return 1
+	}
+
+	return 0
+}
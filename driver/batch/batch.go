@@ -0,0 +1,263 @@
+// Package batch parses all the Python files of a directory tree concurrently,
+// with the parser package, skipping the files ignored by .gitignore.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/python-driver/driver/parser"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// DefaultInclude are the globs of the files parsed by default.
+var DefaultInclude = []string{"*.py", "*.pyi"}
+
+// Config is the configuration of Run.
+type Config struct {
+	// Root is the directory to parse.
+	Root string
+	// Include and Exclude are globs of the files (and directories, for
+	// Exclude) with the syntax of .gitignore, relative to Root. Include is
+	// DefaultInclude if empty.
+	Include, Exclude []string
+	// Workers is the number of files parsed concurrently, the number of CPUs
+	// if 0.
+	Workers int
+	// Options are the options of the parser. If its Pool is nil, a Pool of
+	// Workers native drivers is used.
+	Options parser.Options
+}
+
+// Result is the result of parsing a file.
+type Result struct {
+	// Path is the slash separated path of the file, relative to the root.
+	Path    string
+	UAST    *uast.Node
+	Err     error
+	Elapsed time.Duration
+}
+
+// Summary is the summary of a Run.
+type Summary struct {
+	// Files is the number of files parsed.
+	Files int
+	// Failed are the results of the files that couldn't be parsed.
+	Failed  []*Result
+	Elapsed time.Duration
+}
+
+// Files returns the slash separated paths, relative to the root, of the files
+// to parse: the ones matching the include globs and neither the exclude globs
+// nor the .gitignore files of their directories.
+func Files(root string, include, exclude []string) ([]string, error) {
+	if len(include) == 0 {
+		include = DefaultInclude
+	}
+
+	inc, exc := globs(include), globs(exclude)
+	ignores := make(map[string]patterns)
+	var files []string
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			ignores[""], err = readGitignore(root, "")
+			return err
+		}
+
+		parent := path.Dir(rel)
+		if parent == "." {
+			parent = ""
+		}
+
+		ps := ignores[parent]
+		if info.IsDir() {
+			if info.Name() == ".git" || ignored(ps, exc, rel, true) {
+				return filepath.SkipDir
+			}
+
+			own, err := readGitignore(root, rel)
+			ignores[rel] = append(ps[:len(ps):len(ps)], own...)
+			return err
+		}
+
+		if !info.Mode().IsRegular() || ignored(ps, exc, rel, false) {
+			return nil
+		}
+
+		if _, ok := inc.ignored(rel, false); ok {
+			files = append(files, rel)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+func ignored(gitignore, exclude patterns, rel string, isDir bool) bool {
+	if _, ok := gitignore.ignored(rel, isDir); ok {
+		return true
+	}
+
+	_, ok := exclude.ignored(rel, isDir)
+	return ok
+}
+
+// Run parses the files of the root directory, calling handle with the result
+// of each one, never concurrently. It stops at the first error of handle.
+func Run(ctx context.Context, cfg *Config, handle func(*Result) error) (*Summary, error) {
+	start := time.Now()
+	files, err := Files(cfg.Root, cfg.Include, cfg.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	opts := cfg.Options
+	if opts.Pool == nil {
+		opts.Pool = parser.NewPool(parser.PoolConfig{
+			Native: opts.Native,
+			Args:   opts.Args,
+			Size:   workers,
+		})
+
+		defer opts.Pool.Close()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	paths := make(chan string)
+	go func() {
+		defer close(paths)
+		for _, f := range files {
+			select {
+			case paths <- f:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make(chan *Result)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range paths {
+				results <- parse(ctx, cfg.Root, p, &opts)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	s := &Summary{}
+	var handleErr error
+	for r := range results {
+		if handleErr != nil {
+			continue
+		}
+
+		s.Files++
+		if r.Err != nil {
+			s.Failed = append(s.Failed, r)
+		}
+
+		if handleErr = handle(r); handleErr != nil {
+			cancel()
+		}
+	}
+
+	s.Elapsed = time.Since(start)
+	if handleErr == nil {
+		handleErr = ctx.Err()
+	}
+
+	return s, handleErr
+}
+
+func parse(ctx context.Context, root, rel string, opts *parser.Options) *Result {
+	start := time.Now()
+	r := &Result{Path: rel}
+	source, err := ioutil.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	if err == nil {
+		fopts := *opts
+		fopts.Filename = rel
+		r.UAST, err = parser.Parse(ctx, source, &fopts)
+	}
+
+	r.Err, r.Elapsed = err, time.Since(start)
+	return r
+}
+
+// DirWriter returns a handler for Run writing the UAST of each file, as JSON,
+// to the file with its path plus ".json" in dir.
+func DirWriter(dir string) func(*Result) error {
+	return func(r *Result) error {
+		if r.Err != nil {
+			return nil
+		}
+
+		out := filepath.Join(dir, filepath.FromSlash(r.Path)+".json")
+		if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(r.UAST)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(out, data, 0644)
+	}
+}
+
+// Line is a line of the output of NDJSONWriter.
+type Line struct {
+	Path    string     `json:"path"`
+	UAST    *uast.Node `json:"uast,omitempty"`
+	Error   string     `json:"error,omitempty"`
+	Elapsed float64    `json:"elapsed"`
+}
+
+// NDJSONWriter returns a handler for Run writing a Line for each file to w, as
+// newline delimited JSON.
+func NDJSONWriter(w io.Writer) func(*Result) error {
+	enc := json.NewEncoder(w)
+	return func(r *Result) error {
+		l := &Line{Path: r.Path, UAST: r.UAST, Elapsed: r.Elapsed.Seconds()}
+		if r.Err != nil {
+			l.Error = r.Err.Error()
+		}
+
+		return enc.Encode(l)
+	}
+}
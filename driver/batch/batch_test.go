@@ -0,0 +1,140 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/parser"
+	"github.com/bblfsh/python-driver/driver/parser/fakenative"
+	"github.com/stretchr/testify/require"
+)
+
+var fixtureDir = filepath.Join("..", "..", "fixtures")
+
+func TestMain(m *testing.M) {
+	fakenative.Main(fixtureDir)
+	os.Exit(m.Run())
+}
+
+// tree writes the files in a temporary directory, copying the fixtures named
+// by their contents prefixed with @.
+func tree(t *testing.T, files map[string]string) string {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "python-driver-batch")
+	require.NoError(err)
+
+	for name, content := range files {
+		data := []byte(content)
+		if content != "" && content[0] == '@' {
+			data, err = ioutil.ReadFile(filepath.Join(fixtureDir, content[1:]))
+			require.NoError(err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(ioutil.WriteFile(path, data, 0644))
+	}
+
+	return dir
+}
+
+var files = map[string]string{
+	".gitignore":             "build/\n*_generated.py\n",
+	"a.py":                   "@u2_import_rename.py",
+	"b.pyi":                  "@other_statements.py",
+	"README.md":              "",
+	"pkg/c.py":               "@issue_server101.py",
+	"pkg/c_generated.py":     "",
+	"pkg/.gitignore":         "skip.py\n",
+	"pkg/skip.py":            "",
+	"pkg/tests/test_c.py":    "@u2_import_rename.py",
+	"build/lib/a.py":         "",
+	".git/hooks/x.py":        "",
+	"broken/syntax_error.py": "def f(:\n",
+}
+
+func TestFiles(t *testing.T) {
+	require := require.New(t)
+
+	dir := tree(t, files)
+	defer os.RemoveAll(dir)
+
+	fs, err := Files(dir, nil, nil)
+	require.NoError(err)
+	require.Equal([]string{"a.py", "b.pyi", "broken/syntax_error.py", "pkg/c.py", "pkg/tests/test_c.py"}, fs)
+
+	fs, err = Files(dir, []string{"*.py"}, []string{"tests/", "broken"})
+	require.NoError(err)
+	require.Equal([]string{"a.py", "pkg/c.py"}, fs)
+}
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+
+	dir := tree(t, files)
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	s, err := Run(context.Background(), &Config{
+		Root:    dir,
+		Workers: 2,
+		Options: parser.Options{Native: os.Args[0]},
+	}, NDJSONWriter(&buf))
+
+	require.NoError(err)
+	require.Equal(5, s.Files)
+	require.Len(s.Failed, 1)
+	require.Equal("broken/syntax_error.py", s.Failed[0].Path)
+
+	var paths []string
+	scanner := bufio.NewScanner(&buf)
+	scanner.Buffer(nil, 1<<24)
+	for scanner.Scan() {
+		var l Line
+		require.NoError(json.Unmarshal(scanner.Bytes(), &l))
+		paths = append(paths, l.Path)
+		if l.Path == "broken/syntax_error.py" {
+			require.Nil(l.UAST)
+			require.Contains(l.Error, "SyntaxError")
+		} else {
+			require.Equal("Module", l.UAST.InternalType)
+			require.Empty(l.Error)
+		}
+	}
+
+	require.NoError(scanner.Err())
+	sort.Strings(paths)
+	require.Equal([]string{"a.py", "b.pyi", "broken/syntax_error.py", "pkg/c.py", "pkg/tests/test_c.py"}, paths)
+}
+
+func TestDirWriter(t *testing.T) {
+	require := require.New(t)
+
+	dir := tree(t, files)
+	defer os.RemoveAll(dir)
+
+	out, err := ioutil.TempDir("", "python-driver-batch-out")
+	require.NoError(err)
+	defer os.RemoveAll(out)
+
+	_, err = Run(context.Background(), &Config{
+		Root:    dir,
+		Include: []string{"*.py"},
+		Exclude: []string{"broken"},
+		Options: parser.Options{Native: os.Args[0]},
+	}, DirWriter(out))
+	require.NoError(err)
+
+	for _, name := range []string{"a.py", "pkg/c.py", "pkg/tests/test_c.py"} {
+		_, err := os.Stat(filepath.Join(out, filepath.FromSlash(name)+".json"))
+		require.NoError(err, name)
+	}
+}
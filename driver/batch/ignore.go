@@ -0,0 +1,154 @@
+package batch
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// pattern is a pattern of a .gitignore file, or an include or exclude glob.
+type pattern struct {
+	// dir is the slash separated directory of the .gitignore, relative to the
+	// root, empty for the root itself.
+	dir      string
+	segments []string
+	negate   bool
+	dirOnly  bool
+	// anchored patterns match the path from dir, the others only its name.
+	anchored bool
+}
+
+// parsePattern parses a line of a .gitignore file in dir, returning nil for
+// the blank lines and comments.
+func parsePattern(dir, line string) *pattern {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	p := &pattern{dir: dir}
+	if strings.HasPrefix(line, "!") {
+		p.negate, line = true, line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly, line = true, strings.TrimRight(line, "/")
+	}
+
+	p.anchored = strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if line == "" {
+		return nil
+	}
+
+	p.segments = strings.Split(line, "/")
+	return p
+}
+
+// match returns true if the pattern matches the slash separated path, relative
+// to the root.
+func (p *pattern) match(rel string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	if p.dir != "" {
+		if !strings.HasPrefix(rel, p.dir+"/") {
+			return false
+		}
+
+		rel = rel[len(p.dir)+1:]
+	}
+
+	if !p.anchored {
+		ok, _ := path.Match(p.segments[0], path.Base(rel))
+		return ok
+	}
+
+	return matchSegments(p.segments, strings.Split(rel, "/"))
+}
+
+// matchSegments matches the segments of a path with the ones of a pattern,
+// where ** matches any number of segments.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+
+		pattern, segments = pattern[1:], segments[1:]
+	}
+
+	return len(segments) == 0
+}
+
+// patterns is a list of patterns where the last matching one wins.
+type patterns []*pattern
+
+// ignored returns whether the path is matched and, if so, not negated.
+func (ps patterns) ignored(rel string, isDir bool) (matched, ignored bool) {
+	for i := len(ps) - 1; i >= 0; i-- {
+		if ps[i].match(rel, isDir) {
+			return true, !ps[i].negate
+		}
+	}
+
+	return false, false
+}
+
+// readPatterns reads the patterns of a .gitignore file.
+func readPatterns(dir string, r io.Reader) (patterns, error) {
+	var ps patterns
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if p := parsePattern(dir, s.Text()); p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps, s.Err()
+}
+
+// readGitignore reads the .gitignore of a directory, if any.
+func readGitignore(root, dir string) (patterns, error) {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(dir), ".gitignore"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+	return readPatterns(dir, f)
+}
+
+// globs parses include or exclude globs, with the syntax of .gitignore.
+func globs(list []string) patterns {
+	var ps patterns
+	for _, g := range list {
+		if p := parsePattern("", g); p != nil {
+			ps = append(ps, p)
+		}
+	}
+
+	return ps
+}
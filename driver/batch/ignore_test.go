@@ -0,0 +1,47 @@
+package batch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatterns(t *testing.T) {
+	require := require.New(t)
+
+	ps, err := readPatterns("sub", strings.NewReader(`
+# comment
+*.pyc
+build/
+/local.py
+docs/**/*.py
+!keep.pyc
+\!bang.py
+`))
+	require.NoError(err)
+	require.Len(ps, 6)
+
+	for _, c := range []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"sub/a.pyc", false, true},
+		{"sub/x/y/a.pyc", false, true},
+		{"a.pyc", false, false},
+		{"sub/keep.pyc", false, false},
+		{"sub/build", true, true},
+		{"sub/x/build", true, true},
+		{"sub/build", false, false},
+		{"sub/local.py", false, true},
+		{"sub/x/local.py", false, false},
+		{"sub/docs/a.py", false, true},
+		{"sub/docs/x/y/a.py", false, true},
+		{"sub/src/docs/a.py", false, false},
+		{"sub/!bang.py", false, true},
+	} {
+		_, ignored := ps.ignored(c.path, c.isDir)
+		require.Equal(c.ignored, ignored, c.path)
+	}
+}
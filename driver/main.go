@@ -1,11 +1,312 @@
 package main
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
 	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/parser"
 
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
 	"gopkg.in/bblfsh/sdk.v1/sdk/driver"
+	"gopkg.in/bblfsh/sdk.v1/uast"
 )
 
 func main() {
-	driver.Run(normalizer.ToNode, normalizer.Transformers)
+	mode, err := normalizer.ParseMode(os.Getenv(normalizer.ModeEnv))
+	if err != nil {
+		panic(err)
+	}
+
+	rules := normalizer.AnnotationRules
+	if path := os.Getenv(normalizer.RulesEnv); path != "" {
+		overlay, err := normalizer.LoadRules(path)
+		if err != nil {
+			panic(err)
+		}
+
+		rules = normalizer.Overlay(rules, overlay)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == batchCommand {
+		os.Exit(runBatch(os.Args[2:], mode, rules))
+	}
+
+	d, err := driver.NewDriver(normalizer.ToNodeFor(mode), normalizer.TransformersWithRules(mode, rules))
+	if err != nil {
+		panic(err)
+	}
+
+	version, err := parser.ParsePythonVersion(os.Getenv(pythonVersionEnv))
+	if err != nil {
+		panic(err)
+	}
+
+	pool, err := newPool()
+	if err != nil {
+		panic(err)
+	}
+
+	defer pool.Close()
+
+	cache, err := newCache()
+	if err != nil {
+		panic(err)
+	}
+
+	s := driver.NewServer(d)
+	t := &transcoder{opts: parser.Options{
+		Mode: mode, Rules: rules, PythonVersion: version, Pool: pool, Cache: cache,
+	}}
+	s.Options = append(s.Options, grpc.UnaryInterceptor(t.intercept))
+	if err := s.Start(); err != nil {
+		panic(err)
+	}
+}
+
+const (
+	// pythonVersionEnv is the environment variable with the default Python
+	// version of the requests: 2, 3 or auto (the default).
+	pythonVersionEnv = "PYTHON_DRIVER_PYTHON_VERSION"
+	// pythonVersionMetadata is the gRPC metadata key setting the Python
+	// version of a request.
+	pythonVersionMetadata = "python-version"
+
+	// The environment variables configuring the pool of native drivers (see
+	// parser.PoolConfig).
+	workersEnv     = "PYTHON_DRIVER_WORKERS"
+	timeoutEnv     = "PYTHON_DRIVER_TIMEOUT"
+	maxRequestsEnv = "PYTHON_DRIVER_MAX_REQUESTS"
+	maxRSSEnv      = "PYTHON_DRIVER_MAX_RSS"
+
+	// cacheEnv is the environment variable with the directory of the cache
+	// of native ASTs and UASTs, disabled if empty, and cacheSizeEnv the one
+	// with its maximum size in bytes (see parser.CacheConfig).
+	cacheEnv     = "PYTHON_DRIVER_CACHE"
+	cacheSizeEnv = "PYTHON_DRIVER_CACHE_SIZE"
+)
+
+// newPool returns the pool of native drivers configured by the environment.
+func newPool() (*parser.Pool, error) {
+	cfg := parser.PoolConfig{Timeout: time.Minute}
+	size, err := envInt(workersEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := envInt(maxRequestsEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxRSS, err = envInt(maxRSSEnv); err != nil {
+		return nil, err
+	}
+
+	if s := os.Getenv(timeoutEnv); s != "" {
+		if cfg.Timeout, err = time.ParseDuration(s); err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", timeoutEnv, err)
+		}
+	}
+
+	cfg.Size, cfg.MaxRequests = int(size), int(requests)
+	return parser.NewPool(cfg), nil
+}
+
+// newCache returns the cache configured by the environment, nil if disabled.
+func newCache() (*parser.Cache, error) {
+	dir := os.Getenv(cacheEnv)
+	if dir == "" {
+		return nil, nil
+	}
+
+	size, err := envInt(cacheSizeEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.NewCache(parser.CacheConfig{Dir: dir, MaxSize: size})
+}
+
+// envInt returns the integer in an environment variable, 0 if it's not set.
+func envInt(env string) (int64, error) {
+	s := os.Getenv(env)
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", env, err)
+	}
+
+	return n, nil
+}
+
+// transcoder answers the parse requests with the parser package, running the
+// native drivers of a parser.Pool instead of the single one of the SDK. The
+// content of the requests is decoded following its PEP 263 coding cookie, and
+// the positions of the resulting UAST refer to the original content. The
+// Python tracebacks of the syntax errors are replaced by the errors formatted
+// by `parser.SyntaxError`.
+//
+// In normalizer.ModeRecover, the code with syntax errors is answered with the
+// Error status, its errors and the recovered UAST or AST. The results are kept
+// in a parser.Cache if one is configured.
+type transcoder struct {
+	// opts are the options of the driver for the parser package.
+	opts parser.Options
+}
+
+// intercept is a `grpc.UnaryServerInterceptor`.
+func (t *transcoder) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	switch r := req.(type) {
+	case *protocol.ParseRequest:
+		data, err := decode(r.Content, r.Encoding)
+		if err != nil {
+			return &protocol.ParseResponse{Response: fatal(err)}, nil
+		}
+
+		opts, err := t.options(ctx, r.Filename)
+		if err != nil {
+			return &protocol.ParseResponse{Response: fatal(err)}, nil
+		}
+
+		return parse(ctx, r.Filename, data, opts), nil
+	case *protocol.NativeParseRequest:
+		data, err := decode(r.Content, r.Encoding)
+		if err != nil {
+			return &protocol.NativeParseResponse{Response: fatal(err)}, nil
+		}
+
+		opts, err := t.options(ctx, r.Filename)
+		if err != nil {
+			return &protocol.NativeParseResponse{Response: fatal(err)}, nil
+		}
+
+		return parseNative(ctx, data, opts), nil
+	}
+
+	return handler(ctx, req)
+}
+
+// options returns the options of the parser package for a request, with the
+// Python version of its metadata, if any.
+func (t *transcoder) options(ctx context.Context, filename string) (*parser.Options, error) {
+	opts := t.opts
+	opts.Filename = filename
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md[pythonVersionMetadata]) > 0 {
+		var err error
+		if opts.PythonVersion, err = parser.ParsePythonVersion(md[pythonVersionMetadata][0]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &opts, nil
+}
+
+// parse answers a request with the parser package.
+func parse(ctx context.Context, filename string, data []byte, opts *parser.Options) *protocol.ParseResponse {
+	resp := &protocol.ParseResponse{Language: "python", Filename: filename}
+	start := time.Now()
+	defer func() { resp.Elapsed = time.Since(start) }()
+
+	n, err := parser.Parse(ctx, data, opts)
+	if err != nil {
+		resp.Response = failed(err)
+		return resp
+	}
+
+	resp.Status, resp.UAST = protocol.Ok, n
+	if n.InternalType == "Module" {
+		recovered(&resp.Response, n.Children)
+	}
+
+	return resp
+}
+
+// recovered sets the Error status and the messages of the Error nodes of
+// normalizer.ModeRecover in the top-level statements, if any.
+func recovered(resp *protocol.Response, stmts []*uast.Node) {
+	for _, s := range stmts {
+		if s.InternalType == normalizer.Error {
+			resp.Status = protocol.Error
+			resp.Errors = append(resp.Errors, s.Properties[normalizer.ErrorMessageKey])
+		}
+	}
+}
+
+// parseNative answers a native request with the parser package.
+func parseNative(ctx context.Context, data []byte, opts *parser.Options) *protocol.NativeParseResponse {
+	resp := &protocol.NativeParseResponse{Language: "python"}
+	start := time.Now()
+	defer func() { resp.Elapsed = time.Since(start) }()
+
+	ast, err := parser.ParseNative(ctx, data, opts)
+	if err == nil {
+		var js []byte
+		if js, err = json.Marshal(ast); err == nil {
+			resp.Status, resp.AST = protocol.Ok, string(js)
+			recoveredNative(&resp.Response, ast)
+			return resp
+		}
+	}
+
+	resp.Response = failed(err)
+	return resp
+}
+
+// recoveredNative is recovered for the statements of a native AST.
+func recoveredNative(resp *protocol.Response, ast map[string]interface{}) {
+	for _, root := range ast {
+		module, _ := root.(map[string]interface{})
+		stmts, _ := module["body"].([]interface{})
+		for _, s := range stmts {
+			if s, ok := s.(map[string]interface{}); ok && s[normalizer.ToNode.InternalTypeKey] == normalizer.Error {
+				resp.Status = protocol.Error
+				msg, _ := s[normalizer.ErrorMessageKey].(string)
+				resp.Errors = append(resp.Errors, msg)
+			}
+		}
+	}
+}
+
+// failed returns the fatal response of an error of the parser package.
+func failed(err error) protocol.Response {
+	es := parser.AsSyntaxErrors(err)
+	if es == nil {
+		return fatal(err)
+	}
+
+	resp := protocol.Response{Status: protocol.Fatal}
+	for _, e := range es {
+		resp.Errors = append(resp.Errors, e.Error())
+	}
+
+	return resp
+}
+
+// decode returns the original bytes of the content of a request.
+func decode(content string, e protocol.Encoding) ([]byte, error) {
+	if e == protocol.Base64 {
+		return base64.StdEncoding.DecodeString(content)
+	}
+
+	return []byte(content), nil
+}
+
+func fatal(err error) protocol.Response {
+	return protocol.Response{
+		Status: protocol.Fatal,
+		Errors: []string{err.Error()},
+	}
 }
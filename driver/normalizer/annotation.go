@@ -7,9 +7,6 @@ import (
 
 	"gopkg.in/bblfsh/sdk.v1/uast"
 	. "gopkg.in/bblfsh/sdk.v1/uast/ann"
-	"gopkg.in/bblfsh/sdk.v1/uast/transformer"
-	"gopkg.in/bblfsh/sdk.v1/uast/transformer/annotatter"
-	"gopkg.in/bblfsh/sdk.v1/uast/transformer/positioner"
 )
 
 /*
@@ -43,10 +40,7 @@ Unmarked nodes or nodes needing new features from the SDK:
 // Transformers is the of list `transformer.Transfomer` to apply to a UAST, to
 // learn more about the Transformers and the available ones take a look to:
 // https://godoc.org/gopkg.in/bblfsh/sdk.v1/uast/transformers
-var Transformers = []transformer.Tranformer{
-	annotatter.NewAnnotatter(AnnotationRules),
-	positioner.NewFillOffsetFromLineCol(),
-}
+var Transformers = TransformersFor(ModeDefault)
 
 // Common for FunctionDef, AsyncFunctionDef and Lambda
 var argumentsAnn = On(pyast.Arguments).Roles(uast.Function, uast.Declaration, uast.Incomplete, uast.Argument).Children(
@@ -148,6 +142,7 @@ var AnnotationRules = On(Any).Self(
 		//		defaults  [2,3]
 		// TODO: create an issue for the SDK
 		On(pyast.ArgumentDefaults).Roles(uast.Function, uast.Declaration, uast.Argument, uast.Value, uast.Incomplete),
+		On(pyast.ArgumentKwDefaults).Roles(uast.Function, uast.Declaration, uast.Argument, uast.Value, uast.Incomplete),
 		On(pyast.AsyncFuncDecorators).Roles(uast.Function, uast.Declaration, uast.Call, uast.Incomplete),
 		On(pyast.AsyncFuncDefBody).Roles(uast.Function, uast.Declaration, uast.Body),
 		// FIXME: change to Function, Declaration, ArgumentS once the PR has been merged
@@ -210,6 +205,13 @@ var AnnotationRules = On(Any).Self(
 				On(Not(HasProperty("noop_line", "\n"))).Roles(uast.Noop, uast.Comment),
 			),
 		),
+		// Comments bound to their statements by the CommentBinder
+		On(HasInternalRole(CommentsLeading)).Roles(uast.Noop, uast.Comment),
+		On(HasInternalRole(CommentsHeader)).Roles(uast.Noop, uast.Comment),
+		// Comments classified by the PragmaClassifier
+		On(Or(HasInternalType(Shebang), HasInternalType(EncodingDeclaration), HasInternalType(Pragma))).Roles(uast.Noop, uast.Comment),
+		// Statements with syntax errors, replaced in ModeRecover
+		On(HasInternalType(Error)).Roles(uast.Statement, uast.Incomplete),
 
 		// TODO: check what Constant nodes are generated in the python AST and improve this
 		On(pyast.Constant).Roles(uast.Identifier, uast.Expression),
@@ -219,16 +221,26 @@ var AnnotationRules = On(Any).Self(
 			On(pyast.TryHandlers).Roles(uast.Try, uast.Catch),
 			On(pyast.TryElse).Roles(uast.Try, uast.Body, uast.Else),
 		),
-		On(pyast.TryExcept).Roles(uast.Try, uast.Catch, uast.Statement),     // py2
+		On(pyast.TryExcept).Roles(uast.Try, uast.Catch, uast.Statement).Children( // py2
+			On(pyast.TryExceptBody).Roles(uast.Try, uast.Body),
+			On(pyast.TryExceptHandlers).Roles(uast.Try, uast.Catch),
+			On(pyast.TryExceptElse).Roles(uast.Try, uast.Body, uast.Else),
+		),
 		On(pyast.ExceptHandler).Roles(uast.Try, uast.Catch, uast.Statement), // py3
 		On(pyast.ExceptHandlerName).Roles(uast.Try, uast.Catch, uast.Identifier),
-		On(pyast.TryFinally).Roles(uast.Try, uast.Finally, uast.Statement),
+		On(pyast.ExceptHandlerBody).Roles(uast.Try, uast.Catch, uast.Body),
+		On(pyast.TryFinally).Roles(uast.Try, uast.Finally, uast.Statement).Children(
+			On(pyast.TryFinallyBody).Roles(uast.Try, uast.Body),
+			On(pyast.TryFinallyFinalBody).Roles(uast.Try, uast.Finally),
+		),
 		On(pyast.Raise).Roles(uast.Throw, uast.Statement),
 		// FIXME: review, add path for the body and items childs
 		On(pyast.With).Roles(uast.Block, uast.Scope, uast.Statement),
 		On(pyast.WithBody).Roles(uast.Block, uast.Scope, uast.Expression, uast.Incomplete),
 		On(pyast.WithItems).Roles(uast.Identifier, uast.Expression, uast.Incomplete),
 		On(pyast.AsyncWith).Roles(uast.Block, uast.Scope, uast.Statement, uast.Incomplete),
+		On(pyast.AsyncWithBody).Roles(uast.Block, uast.Scope, uast.Expression, uast.Incomplete),
+		On(pyast.AsyncWithItems).Roles(uast.Identifier, uast.Expression, uast.Incomplete),
 		On(pyast.Withitem).Roles(uast.Identifier, uast.Incomplete),
 		On(pyast.Return).Roles(uast.Return, uast.Statement),
 		On(pyast.Break).Roles(uast.Break, uast.Statement),
@@ -320,6 +332,9 @@ var AnnotationRules = On(Any).Self(
 		On(pyast.AnnAssign).Roles(uast.Operator, uast.Binary, uast.Assignment),
 		On(HasInternalRole("annotation")).Roles(uast.Annotation),
 		On(HasInternalRole("returns")).Roles(uast.Annotation),
+		On(HasInternalRole("varargannotation")).Roles(uast.Annotation),
+		On(HasInternalRole("kwargannotation")).Roles(uast.Annotation),
+		On(HasInternalRole(ForwardReference)).Roles(uast.Annotation),
 
 		// Python very odd ellipsis operator. Has a special rule in tonoder synthetic tokens
 		// map to load it with the token "PythonEllipsisuast.Operator" and gets the role uast.Identifier
@@ -331,11 +346,15 @@ var AnnotationRules = On(Any).Self(
 		On(pyast.ListComp).Roles(uast.List, uast.For, uast.Expression),
 		On(pyast.DictComp).Roles(uast.Map, uast.For, uast.Expression),
 		On(pyast.SetComp).Roles(uast.Set, uast.For, uast.Expression),
+		On(Or(pyast.ListCompGenerators, pyast.DictCompGenerators, pyast.SetCompGenerators, pyast.GeneratorExpGenerators)).
+			Roles(uast.For, uast.Iterator, uast.Incomplete),
 		On(pyast.Comprehension).Roles(uast.For, uast.Iterator, uast.Expression, uast.Incomplete).Children(
 			On(HasInternalRole("iter")).Roles(uast.For, uast.Update, uast.Statement),
 			On(HasInternalRole("target")).Roles(uast.For, uast.Expression),
 			// FIXME: see the comment on uast.If, uast.Condition above
-			On(pyast.Compare).Roles(uast.If, uast.Condition),
+			On(pyast.ComprehensionIfs).Roles(uast.If, uast.Condition).Children(
+				On(pyast.Compare).Roles(uast.If, uast.Condition),
+			),
 		),
 
 		On(pyast.Delete).Roles(uast.Statement, uast.Incomplete),
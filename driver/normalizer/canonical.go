@@ -0,0 +1,435 @@
+package normalizer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Properties set by the Canonicalizer.
+const (
+	// OriginalTokenKey is the token of a renamed name or of an abstracted
+	// literal.
+	OriginalTokenKey = "originalToken"
+	// StructuralHashKey is the SHA-256 of the canonical structure of a
+	// function or a class, in hexadecimal.
+	StructuralHashKey = "structuralHash"
+)
+
+// Canonicalizer is a `transformer.Tranformer` that renames the variables of
+// the UAST to a canonical form, for finding the code copied under other names.
+//
+// The local variables, parameters and inner functions and classes of each
+// function scope (functions, lambdas and comprehensions) are renamed to v0,
+// v1... in the order of their first appearance in the source. The numbering
+// is shared by the nested scopes, so the names stay unique. The globals, the
+// builtins, the imported modules, the attributes, the keyword arguments and
+// the names at the module and class level are kept.
+//
+// Every function and class gets a StructuralHashKey property with the hash of
+// its types, internal roles and tokens, ignoring its own name, the positions,
+// the comments and the types from comments. The renamed names are numbered
+// again from its start, so the copies of a function or a class have the same
+// hash wherever they are.
+type Canonicalizer struct {
+	// Literals replaces the tokens of the strings and bytes by "" and the ones
+	// of the numbers by 0.
+	Literals bool
+}
+
+// NewCanonicalizer returns a new Canonicalizer, abstracting the literals if
+// literals is true.
+func NewCanonicalizer(literals bool) *Canonicalizer {
+	return &Canonicalizer{Literals: literals}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *Canonicalizer) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	c := &canonicalizer{
+		py2:     n.Properties[PythonVersionKey] == "2",
+		renamed: make(map[*uast.Node]bool),
+	}
+
+	c.visit(n, newScope(nil, false), 0)
+	c.rename()
+	if t.Literals {
+		abstractLiterals(n)
+	}
+
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		switch p.Node().InternalType {
+		case "FunctionDef", "AsyncFunctionDef", "ClassDef":
+			p.Node().Properties[StructuralHashKey] = c.hash(p.Node())
+		}
+	}
+
+	return nil
+}
+
+// scope is a Python scope: a function, lambda or comprehension one, or a
+// module or class one, whose names are kept.
+type scope struct {
+	parent   *scope
+	function bool
+	bound    map[string]bool
+	global   map[string]bool
+	nonlocal map[string]bool
+	imported map[string]bool
+	// unit numbers the names of the outermost function scope and the ones
+	// nested in it.
+	unit *unit
+}
+
+type unit struct {
+	names map[*scope]map[string]string
+	count int
+}
+
+func newScope(parent *scope, function bool) *scope {
+	s := &scope{
+		parent:   parent,
+		function: function,
+		bound:    make(map[string]bool),
+		global:   make(map[string]bool),
+		nonlocal: make(map[string]bool),
+		imported: make(map[string]bool),
+	}
+
+	if parent != nil {
+		s.unit = parent.unit
+	}
+
+	if function && s.unit == nil {
+		s.unit = &unit{names: make(map[*scope]map[string]string)}
+	}
+
+	return s
+}
+
+// resolve returns the function scope where a name used in s is a local, or
+// nil if it's kept. The class scopes are skipped by the scopes nested in them.
+func (s *scope) resolve(name string) *scope {
+	for c := s; c != nil; c = c.parent {
+		switch {
+		case c.global[name]:
+			return nil
+		case c.nonlocal[name]:
+		case !c.function:
+			if c == s && c.bound[name] {
+				return nil
+			}
+		case c.imported[name]:
+			return nil
+		case c.bound[name]:
+			return c
+		}
+	}
+
+	return nil
+}
+
+// placeholder returns the name of a local of s.
+func (u *unit) placeholder(s *scope, name string) string {
+	if u.names[s] == nil {
+		u.names[s] = make(map[string]string)
+	}
+
+	p, ok := u.names[s][name]
+	if !ok {
+		p = "v" + strconv.Itoa(u.count)
+		u.count++
+		u.names[s][name] = p
+	}
+
+	return p
+}
+
+// occurrence is a name in the UAST, in a token or a property.
+type occurrence struct {
+	node *uast.Node
+	// property holds the name, or the token if empty.
+	property string
+	name     string
+	scope    *scope
+	offset   uint32
+}
+
+type canonicalizer struct {
+	py2         bool
+	occurrences []*occurrence
+	renamed     map[*uast.Node]bool
+}
+
+func (c *canonicalizer) add(n *uast.Node, property, name string, s *scope, offset uint32) {
+	c.occurrences = append(c.occurrences, &occurrence{
+		node: n, property: property, name: name, scope: s, offset: offset,
+	})
+}
+
+// visit collects the names of n and its descendants in the scope s. The nodes
+// without position are at the offset of their parent.
+func (c *canonicalizer) visit(n *uast.Node, s *scope, offset uint32) {
+	if n.StartPosition != nil {
+		offset = n.StartPosition.Offset
+	}
+
+	if isTrivia(n) {
+		return
+	}
+
+	switch n.InternalType {
+	case "FunctionDef", "AsyncFunctionDef", "ClassDef":
+		s.bound[n.Token] = true
+		c.add(n, "", n.Token, s, offset)
+
+		inner := newScope(s, n.InternalType != "ClassDef")
+		for _, ch := range n.Children {
+			switch ch.InternalType {
+			case n.InternalType + ".body":
+				c.visitChildren(ch, inner, offset)
+			case "arguments":
+				c.arguments(ch, s, inner, offset)
+			default:
+				c.visit(ch, s, offset)
+			}
+		}
+
+		return
+	case "Lambda":
+		inner := newScope(s, true)
+		for _, ch := range n.Children {
+			if ch.InternalType == "arguments" {
+				c.arguments(ch, s, inner, offset)
+			} else {
+				c.visit(ch, inner, offset)
+			}
+		}
+
+		return
+	case "ListComp", "SetComp", "DictComp", "GeneratorExp":
+		if n.InternalType == "ListComp" && c.py2 {
+			// the variables of the list comprehensions leak in Python 2
+			break
+		}
+
+		c.comprehension(n, s, offset)
+		return
+	case "Global", "Nonlocal":
+		for _, ch := range n.Children {
+			if ch.InternalType != "Name" {
+				continue
+			}
+
+			if n.InternalType == "Global" {
+				s.global[ch.Token] = true
+			} else {
+				s.nonlocal[ch.Token] = true
+			}
+
+			c.add(ch, "", ch.Token, s, offset)
+		}
+
+		return
+	case "alias":
+		name := n.Token
+		if as := n.Properties["alias.asname"]; as != "" {
+			name = as
+		}
+
+		s.imported[strings.Split(name, ".")[0]] = true
+		return
+	case "Name":
+		switch n.Properties["ctx"] {
+		case "Store", "Del", "Param", "AugStore":
+			s.bound[n.Token] = true
+		}
+
+		c.add(n, "", n.Token, s, offset)
+	case "ExceptHandler":
+		if name := n.Properties["ExceptHandler.name"]; name != "" {
+			s.bound[name] = true
+			c.add(n, "ExceptHandler.name", name, s, offset)
+		}
+	case "ExceptHandler.name":
+		c.add(n, "", n.Token, s, offset)
+	}
+
+	c.visitChildren(n, s, offset)
+}
+
+func (c *canonicalizer) visitChildren(n *uast.Node, s *scope, offset uint32) {
+	for _, ch := range n.Children {
+		c.visit(ch, s, offset)
+	}
+}
+
+// arguments collects the names of the arguments of a function, whose defaults
+// and annotations are in the scope outer and the parameters in inner.
+func (c *canonicalizer) arguments(n *uast.Node, outer, inner *scope, offset uint32) {
+	for _, ch := range n.Children {
+		switch ch.Properties[uast.InternalRoleKey] {
+		case "args", "posonlyargs", "kwonlyargs", "vararg", "kwarg":
+			if ch.InternalType != "arg" {
+				// the Name and Tuple parameters of Python 2
+				c.visit(ch, inner, offset)
+				continue
+			}
+
+			pos := offset
+			if ch.StartPosition != nil {
+				pos = ch.StartPosition.Offset
+			}
+
+			inner.bound[ch.Token] = true
+			c.add(ch, "", ch.Token, inner, pos)
+			c.visitChildren(ch, outer, pos)
+		default:
+			c.visit(ch, outer, offset)
+		}
+	}
+
+	// the variable arguments of Python 2
+	for _, key := range []string{"vararg", "kwarg"} {
+		if name := n.Properties[key]; name != "" {
+			inner.bound[name] = true
+			c.add(n, key, name, inner, offset)
+		}
+	}
+}
+
+// comprehension collects the names of a comprehension, whose first iterable
+// is in the scope outer.
+func (c *canonicalizer) comprehension(n *uast.Node, outer *scope, offset uint32) {
+	inner := newScope(outer, true)
+	first := true
+	for _, ch := range n.Children {
+		if ch.InternalType != n.InternalType+".generators" {
+			c.visit(ch, inner, offset)
+			continue
+		}
+
+		for _, g := range ch.Children {
+			for _, gc := range g.Children {
+				if first && gc.Properties[uast.InternalRoleKey] == "iter" {
+					c.visit(gc, outer, offset)
+				} else {
+					c.visit(gc, inner, offset)
+				}
+			}
+
+			first = false
+		}
+	}
+}
+
+// rename renames the locals in the order of their offsets.
+func (c *canonicalizer) rename() {
+	sort.SliceStable(c.occurrences, func(i, j int) bool {
+		return c.occurrences[i].offset < c.occurrences[j].offset
+	})
+
+	for _, o := range c.occurrences {
+		s := o.scope.resolve(o.name)
+		if s == nil {
+			continue
+		}
+
+		p := s.unit.placeholder(s, o.name)
+		if o.property != "" {
+			o.node.Properties[o.property] = p
+			continue
+		}
+
+		if _, ok := o.node.Properties[OriginalTokenKey]; !ok {
+			o.node.Properties[OriginalTokenKey] = o.node.Token
+		}
+
+		o.node.Token = p
+		c.renamed[o.node] = true
+	}
+}
+
+// abstractLiterals replaces the tokens of the literals of n.
+func abstractLiterals(n *uast.Node) {
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		l := p.Node()
+		var token string
+		switch l.InternalType {
+		case "Str", "Bytes":
+		case "Num":
+			token = "0"
+		default:
+			continue
+		}
+
+		if _, ok := l.Properties[OriginalTokenKey]; !ok {
+			l.Properties[OriginalTokenKey] = l.Token
+		}
+
+		l.Token = token
+	}
+}
+
+// hashedProperties are the properties of the nodes in their hash.
+var hashedProperties = []string{uast.InternalRoleKey, "ctx", "level", "is_async", "conversion"}
+
+// hash returns the structural hash of a function or a class.
+func (c *canonicalizer) hash(n *uast.Node) string {
+	h := &hasher{renamed: c.renamed, names: make(map[string]string), self: n.Token}
+	h.write(n, true)
+	sum := sha256.Sum256(h.buf.Bytes())
+	return hex.EncodeToString(sum[:])
+}
+
+type hasher struct {
+	buf     bytes.Buffer
+	renamed map[*uast.Node]bool
+	// names are the renamed names numbered again.
+	names map[string]string
+	// self is the name of the hashed node, which can be used in its body.
+	self string
+}
+
+func (h *hasher) write(n *uast.Node, root bool) {
+	if isTrivia(n) || n.Properties[TypeCommentKey] == "true" ||
+		n.Properties[uast.InternalRoleKey] == ForwardReference {
+		return
+	}
+
+	h.buf.WriteString("(" + n.InternalType)
+	for _, k := range hashedProperties {
+		if v, ok := n.Properties[k]; ok {
+			fmt.Fprintf(&h.buf, " %s=%q", k, v)
+		}
+	}
+
+	token := n.Token
+	switch {
+	case root || n.InternalType == "Name" && token == h.self:
+		token = "<self>"
+	case h.renamed[n]:
+		name, ok := h.names[token]
+		if !ok {
+			name = "v" + strconv.Itoa(len(h.names))
+			h.names[token] = name
+		}
+
+		token = name
+	}
+
+	fmt.Fprintf(&h.buf, " %q", token)
+	for _, c := range n.Children {
+		h.write(c, false)
+	}
+
+	h.buf.WriteString(")")
+}
@@ -0,0 +1,179 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+const canonicalCode = `def f(a, b=1):
+    c = len(a) + b
+    return c
+def g(x, y=2):
+    z = len(x) + y
+    return z
+`
+
+// canonicalFunction returns the native AST of a function of canonicalCode.
+func canonicalFunction(line int, name, a, b, c, def string) string {
+	pos := func(l, col int) string {
+		b, _ := json.Marshal(map[string]int{"lineno": line + l, "col_offset": col})
+		return string(b[1 : len(b)-1])
+	}
+
+	return `{"ast_type": "FunctionDef", "name": "` + name + `", ` + pos(0, 5) + `,
+    "args": {"ast_type": "arguments",
+      "args": [
+        {"ast_type": "arg", "arg": "` + a + `", ` + pos(0, 7) + `},
+        {"ast_type": "arg", "arg": "` + b + `", ` + pos(0, 10) + `}
+      ],
+      "defaults": [{"ast_type": "Num", "n": ` + def + `, ` + pos(0, 12) + `}],
+      "kw_defaults": [], "kwonlyargs": []},
+    "body": [{
+      "ast_type": "Assign", ` + pos(1, 5) + `,
+      "targets": [{"ast_type": "Name", "id": "` + c + `", "ctx": "Store", ` + pos(1, 5) + `}],
+      "value": {"ast_type": "BinOp", ` + pos(1, 9) + `,
+        "left": {"ast_type": "Call", ` + pos(1, 9) + `,
+          "func": {"ast_type": "Name", "id": "len", "ctx": "Load", ` + pos(1, 9) + `},
+          "args": [{"ast_type": "Name", "id": "` + a + `", "ctx": "Load", ` + pos(1, 13) + `}],
+          "keywords": []},
+        "op": {"ast_type": "Add"},
+        "right": {"ast_type": "Name", "id": "` + b + `", "ctx": "Load", ` + pos(1, 18) + `}}
+    }, {
+      "ast_type": "Return", ` + pos(2, 5) + `,
+      "value": {"ast_type": "Name", "id": "` + c + `", "ctx": "Load", ` + pos(2, 12) + `}
+    }],
+    "decorator_list": []
+  }`
+}
+
+// canonicalNative is the native AST of canonicalCode.
+var canonicalNative = `{"PY3AST": {"ast_type": "Module", "body": [` +
+	canonicalFunction(1, "f", "a", "b", "c", "1") + `, ` +
+	canonicalFunction(4, "g", "x", "y", "z", "2") + `]}}`
+
+func canonicalize(t *testing.T, m Mode, code string, n *uast.Node) *uast.Node {
+	require := require.New(t)
+	for _, tr := range TransformersFor(m) {
+		require.NoError(tr.Do(code, protocol.UTF8, n))
+	}
+
+	return n
+}
+
+func canonicalFixture(t *testing.T, m Mode, name string) *uast.Node {
+	code, n, err := getDriverFixture(name)
+	require.NoError(t, err)
+	return canonicalize(t, m, code, n)
+}
+
+func canonicalFunctions(t *testing.T, m Mode) (f, g *uast.Node) {
+	require := require.New(t)
+
+	var obj map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(canonicalNative), &obj))
+
+	n, err := ToNode.ToNode(obj)
+	require.NoError(err)
+
+	n = canonicalize(t, m, canonicalCode, n)
+	require.Len(n.Children, 2)
+	return n.Children[0], n.Children[1]
+}
+
+func TestCanonicalizerParameters(t *testing.T) {
+	require := require.New(t)
+
+	// def testfnc1(a, b):
+	//     a = b
+	//     return 1
+	n := canonicalFixture(t, ModeCanonical, "u2_func_simple.py")
+	f := n.Children[0]
+	require.Equal("testfnc1", f.Token)
+	require.NotEmpty(f.Properties[StructuralHashKey])
+
+	args := arguments(child(f, "args"))
+	require.Len(args, 2)
+	require.Equal("v0", args[0].Token)
+	require.Equal("a", args[0].Properties[OriginalTokenKey])
+	require.Equal("v1", args[1].Token)
+
+	assign := findType(f, "Assign")
+	require.Equal("v0", child(assign, "targets").Token)
+	require.Equal("v1", child(assign, "value").Token)
+	require.Equal("1", findType(f, "Num").Token)
+}
+
+func TestCanonicalizerInnerFunctions(t *testing.T) {
+	require := require.New(t)
+
+	n := canonicalFixture(t, ModeCanonical, "u2_func_inner.py")
+	f1, f3 := n.Children[0], n.Children[1]
+	require.Equal("testfnc1", f1.Token)
+	require.Equal("testfnc3", f3.Token)
+
+	// each top-level function has its own numbering, shared by the nested ones
+	f2 := findType(f1, "FunctionDef.body").Children[0]
+	require.Equal("v0", f2.Token)
+	require.Equal("testfnc2", f2.Properties[OriginalTokenKey])
+
+	f4 := findType(f3, "FunctionDef.body").Children[0]
+	require.Equal("v0", f4.Token)
+	f5 := findType(f4, "FunctionDef.body").Children[0]
+	require.Equal("v1", f5.Token)
+}
+
+func TestCanonicalizerGlobals(t *testing.T) {
+	require := require.New(t)
+
+	// a = 1
+	// b = 2
+	// del a
+	// def f():
+	//     global a
+	//     nonlocal b
+	//     yield b
+	n := canonicalFixture(t, ModeCanonical, "other_statements.py")
+	f := findType(n, "FunctionDef")
+	require.Equal("a", findType(f, "Global").Children[0].Token)
+	require.Equal("b", findType(f, "Nonlocal").Children[0].Token)
+	require.Equal("b", child(findType(f, "Yield"), "value").Token)
+
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		_, ok := p.Node().Properties[OriginalTokenKey]
+		require.False(ok, p.Node().Token)
+	}
+}
+
+func TestCanonicalizerHash(t *testing.T) {
+	require := require.New(t)
+
+	f, g := canonicalFunctions(t, ModeCanonical)
+	require.Equal("f", f.Token)
+	require.Equal("g", g.Token)
+	require.Equal("len", child(findType(g, "Call"), "func").Token)
+	require.Equal("v2", child(findType(g, "Return"), "value").Token)
+
+	// the defaults are different
+	require.NotEqual(f.Properties[StructuralHashKey], g.Properties[StructuralHashKey])
+
+	f, g = canonicalFunctions(t, ModeCanonicalLiterals)
+	require.Equal(f.Properties[StructuralHashKey], g.Properties[StructuralHashKey])
+
+	num := findType(g, "Num")
+	require.Equal("0", num.Token)
+	require.Equal("2", num.Properties[OriginalTokenKey])
+}
+
+func TestParseModeCanonical(t *testing.T) {
+	require := require.New(t)
+
+	m, err := ParseMode("canonical-literals")
+	require.NoError(err)
+	require.Equal(ModeCanonicalLiterals, m)
+	require.Equal("canonical-literals", m.String())
+}
@@ -0,0 +1,363 @@
+package normalizer
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Internal roles given by the CommentBinder to the comments it moves.
+const (
+	// CommentsLeading are the comment lines just before a statement.
+	CommentsLeading = "comments_leading"
+	// CommentsTrailing are the comments at the end of the last line of a
+	// statement.
+	CommentsTrailing = "comments_trailing"
+	// CommentsHeader are the comments at the start of the file that are not
+	// followed by a statement without a blank line in between (licenses,
+	// shebangs, module descriptions...).
+	CommentsHeader = "comments_header"
+)
+
+// statementLists are the fields of Python AST nodes holding statements.
+var statementLists = []string{"body", "orelse", "finalbody", "handlers"}
+
+// CommentBinder is a `transformer.Tranformer` that moves the comments found in
+// the PreviousNoops, SameLineNoops and RemainderNoops nodes under the statement
+// they document:
+//
+// - Comment lines are bound to the nearest following statement in the same
+// block, with the CommentsLeading internal role.
+// - Same line comments are bound to the innermost statement including their
+// line, with the CommentsTrailing internal role.
+// - Comment lines before the first statement separated from it by a blank line
+// are left in the Module with the CommentsHeader internal role.
+//
+// Whitespace lines, and comments without a statement to bind to, are left
+// where they were; noop containers left empty are removed.
+type CommentBinder struct{}
+
+// NewCommentBinder returns a new CommentBinder.
+func NewCommentBinder() *CommentBinder {
+	return &CommentBinder{}
+}
+
+type statement struct {
+	node *uast.Node
+	// line is the first line of the node itself while start and end are the
+	// lines spanned by the node and all its descendants (decorators may be
+	// before the line of the node).
+	line, start, end int
+	children         []*statement
+}
+
+type comment struct {
+	node      *uast.Node
+	container *uast.Node
+	line      int
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *CommentBinder) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	b := &binder{code: code, idx: newLineIndex([]byte(code))}
+	b.statements = b.collect(n)
+	if len(b.lines) == 0 && len(b.trailing) == 0 {
+		return nil
+	}
+
+	header := b.headerEnd()
+	for _, c := range b.lines {
+		switch {
+		case c.line < header:
+			b.move(c, n, CommentsHeader)
+		default:
+			if s := leading(b.statements, c.line); s != nil {
+				b.move(c, s.node, CommentsLeading)
+			}
+		}
+	}
+
+	for _, c := range b.trailing {
+		if s := trailing(b.statements, c.line); s != nil {
+			b.move(c, s.node, CommentsTrailing)
+		}
+	}
+
+	b.cleanup(n)
+	return nil
+}
+
+type binder struct {
+	code       string
+	idx        *lineIndex
+	statements []*statement
+	// lines are the comment lines and trailing the same line comments.
+	lines    []*comment
+	trailing []*comment
+	// moved holds the comments moved to each target node, in order.
+	moved map[*uast.Node][]*uast.Node
+	// emptied holds the noop containers that could have been left empty.
+	emptied []*uast.Node
+}
+
+// collect returns the statements under n (the statement tree) and records the
+// comments found along the way.
+func (b *binder) collect(n *uast.Node) []*statement {
+	var stmts []*statement
+	for _, c := range n.Children {
+		switch c.InternalType {
+		case "PreviousNoops", "RemainderNoops":
+			for _, l := range c.Children {
+				if b.isComment(l) {
+					b.lines = append(b.lines, &comment{node: l, container: c, line: line(l)})
+				}
+			}
+			continue
+		case "SameLineNoops":
+			b.trailing = append(b.trailing, &comment{node: c, container: n, line: line(c)})
+			continue
+		}
+
+		children := b.collect(c)
+		if !isStatement(c, n) {
+			stmts = append(stmts, children...)
+			continue
+		}
+
+		s := &statement{node: c, line: line(c), children: children}
+		s.start, s.end = span(c)
+		if s.line == 0 {
+			s.line = s.start
+		}
+
+		stmts = append(stmts, s)
+	}
+
+	sort.SliceStable(stmts, func(i, j int) bool {
+		return stmts[i].start < stmts[j].start
+	})
+
+	return stmts
+}
+
+// isComment checks the source since the token of an empty comment ("#") is
+// just a line break, like the one of a blank line.
+func (b *binder) isComment(n *uast.Node) bool {
+	if n.InternalType != "NoopLine" {
+		return false
+	}
+
+	return strings.HasPrefix(strings.TrimSpace(b.idx.Line(b.code, line(n))), "#")
+}
+
+// isStatement returns true if n is an element of a statement list of parent.
+func isStatement(n, parent *uast.Node) bool {
+	if parent == nil || parent.InternalType == "Lambda.body" {
+		return false
+	}
+
+	for _, l := range statementLists {
+		if strings.HasSuffix(parent.InternalType, "."+l) {
+			return true
+		}
+	}
+
+	switch parent.InternalType {
+	case "IfExp", "Lambda", "Expression", "Interactive":
+		return false
+	}
+
+	role := n.Properties[uast.InternalRoleKey]
+	for _, l := range statementLists {
+		if role == l {
+			return true
+		}
+	}
+
+	return false
+}
+
+func line(n *uast.Node) int {
+	if n.StartPosition == nil {
+		return 0
+	}
+
+	return int(n.StartPosition.Line)
+}
+
+// isTrivia returns true for the noop nodes and the comments bound to them.
+func isTrivia(n *uast.Node) bool {
+	switch n.InternalType {
+	case "PreviousNoops", "SameLineNoops", "RemainderNoops", "NoopLine",
+		Shebang, EncodingDeclaration, Pragma:
+		return true
+	}
+
+	return false
+}
+
+// span returns the first and last lines of n and its descendants, ignoring the
+// noop nodes and the comments bound to them.
+func span(n *uast.Node) (start, end int) {
+	for _, p := range []*uast.Position{n.StartPosition, n.EndPosition} {
+		if p == nil || p.Line == 0 {
+			continue
+		}
+
+		l := int(p.Line)
+		if start == 0 || l < start {
+			start = l
+		}
+
+		if l > end {
+			end = l
+		}
+	}
+
+	for _, c := range n.Children {
+		if isTrivia(c) {
+			continue
+		}
+
+		s, e := span(c)
+		if s != 0 && (start == 0 || s < start) {
+			start = s
+		}
+
+		if e > end {
+			end = e
+		}
+	}
+
+	return start, end
+}
+
+// headerEnd returns the line where the header comments of the file end: the
+// last blank line before the first statement (or the end of the file if
+// there are no statements).
+func (b *binder) headerEnd() int {
+	if len(b.statements) == 0 {
+		return len(b.idx.lines) + 1
+	}
+
+	for l := b.statements[0].start - 1; l >= 1; l-- {
+		if strings.TrimSpace(b.idx.Line(b.code, l)) == "" {
+			return l
+		}
+	}
+
+	return 0
+}
+
+// leading returns the nearest statement following the given line in the same
+// block.
+func leading(stmts []*statement, line int) *statement {
+	for _, s := range stmts {
+		if s.start < line && line <= s.end {
+			// decorators are before the line of the function itself
+			if line < s.line {
+				return s
+			}
+
+			return leading(s.children, line)
+		}
+
+		if s.start > line {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// trailing returns the innermost statement including the given line.
+func trailing(stmts []*statement, line int) *statement {
+	for _, s := range stmts {
+		if s.start <= line && line <= s.end {
+			if inner := trailing(s.children, line); inner != nil {
+				return inner
+			}
+
+			return s
+		}
+	}
+
+	return nil
+}
+
+func (b *binder) move(c *comment, target *uast.Node, role string) {
+	for i, n := range c.container.Children {
+		if n == c.node {
+			c.container.Children = append(c.container.Children[:i], c.container.Children[i+1:]...)
+			break
+		}
+	}
+
+	c.node.Properties[uast.InternalRoleKey] = role
+	if b.moved == nil {
+		b.moved = make(map[*uast.Node][]*uast.Node)
+	}
+
+	b.moved[target] = append(b.moved[target], c.node)
+	// the container of the same line comments is the node they were in
+	if c.node.InternalType == "NoopLine" {
+		b.emptied = append(b.emptied, c.container)
+	}
+}
+
+// cleanup adds the moved comments to their targets, the leading and header
+// ones first, and removes the noop containers left empty.
+func (b *binder) cleanup(n *uast.Node) {
+	empty := make(map[*uast.Node]bool)
+	for _, c := range b.emptied {
+		if len(c.Children) == 0 {
+			empty[c] = true
+		}
+	}
+
+	var walk func(n *uast.Node)
+	walk = func(n *uast.Node) {
+		children := n.Children[:0]
+		for _, c := range n.Children {
+			if !empty[c] {
+				children = append(children, c)
+			}
+		}
+		n.Children = children
+
+		var before, after []*uast.Node
+		for _, c := range b.moved[n] {
+			if c.Properties[uast.InternalRoleKey] == CommentsTrailing {
+				after = append(after, c)
+			} else {
+				before = append(before, c)
+			}
+		}
+
+		if len(before)+len(after) > 0 {
+			n.Children = append(append(before, n.Children...), after...)
+		}
+
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+
+	walk(n)
+}
+
+// Comments returns the comments bound to a statement by the CommentBinder.
+func Comments(n *uast.Node) (leading, trailing []*uast.Node) {
+	for _, c := range n.Children {
+		switch c.Properties[uast.InternalRoleKey] {
+		case CommentsLeading, CommentsHeader:
+			leading = append(leading, c)
+		case CommentsTrailing:
+			trailing = append(trailing, c)
+		}
+	}
+
+	return leading, trailing
+}
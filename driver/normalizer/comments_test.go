@@ -0,0 +1,208 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// driverFixtureDir holds the source files and native ASTs used by the
+// integration tests.
+var driverFixtureDir = filepath.Join("..", "..", "fixtures")
+
+// getDriverFixture returns the source of a file of the integration fixtures
+// and the UAST of its native AST, before applying the Transformers.
+func getDriverFixture(name string) (string, *uast.Node, error) {
+	path := filepath.Join(driverFixtureDir, name)
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	native, err := ioutil.ReadFile(path + ".native")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var resp struct {
+		AST map[string]interface{} `json:"ast"`
+	}
+
+	if err := json.Unmarshal(native, &resp); err != nil {
+		return "", nil, err
+	}
+
+	n, err := ToNode.ToNode(resp.AST)
+	return string(src), n, err
+}
+
+func bindComments(t *testing.T, name string) *uast.Node {
+	require := require.New(t)
+
+	code, n, err := getDriverFixture(name)
+	require.NoError(err)
+
+	err = NewCommentBinder().Do(code, protocol.UTF8, n)
+	require.NoError(err)
+
+	err = AnnotationRules.Apply(n)
+	require.NoError(err)
+	return n
+}
+
+func tokens(nodes []*uast.Node) []string {
+	var tks []string
+	for _, n := range nodes {
+		tks = append(tks, strings.TrimSpace(n.Token))
+	}
+
+	return tks
+}
+
+func TestCommentBinderLeadingAndTrailing(t *testing.T) {
+	require := require.New(t)
+
+	n := bindComments(t, "comments.py")
+	assign := n.Children[0]
+	require.Equal("Assign", assign.InternalType)
+
+	leading, trailing := Comments(assign)
+	require.Equal([]string{"comment above", "second comment above"}, tokens(leading))
+	require.Equal([]string{"[# line trailing comment]"}, tokens(trailing))
+	require.Contains(leading[0].Roles, uast.Comment)
+
+	// the noops left in the target were emptied and removed
+	name := assign.Children[len(leading)]
+	require.Equal("Name", name.InternalType)
+	require.Len(name.Children, 0)
+
+	// there is no statement after the remainder comments
+	remainder := n.Children[1]
+	require.Equal("RemainderNoops", remainder.InternalType)
+	require.Len(remainder.Children, 2)
+}
+
+func TestCommentBinderSameLine(t *testing.T) {
+	require := require.New(t)
+
+	n := bindComments(t, "line_comment.py")
+	pass := n.Children[0]
+	require.Equal("Pass", pass.InternalType)
+
+	leading, trailing := Comments(pass)
+	require.Equal([]string{"thse are", "previous comments"}, tokens(leading))
+	require.Equal([]string{"[# sameline comment]"}, tokens(trailing))
+}
+
+func TestCommentBinderSameLineKeepsNode(t *testing.T) {
+	require := require.New(t)
+
+	// a = 3 # int
+	n := bindComments(t, "literals_assign.py")
+	assign := n.Children[0]
+	require.Equal("Assign", assign.InternalType)
+
+	_, trailing := Comments(assign)
+	require.Equal([]string{"[# int]"}, tokens(trailing))
+
+	var targets int
+	for _, c := range assign.Children {
+		if c.InternalType == "Name" {
+			require.Equal("a", c.Token)
+			targets++
+		}
+	}
+
+	require.Equal(1, targets)
+}
+
+func TestCommentBinderHeader(t *testing.T) {
+	require := require.New(t)
+
+	n := bindComments(t, "issue_server101.py")
+	header, _ := Comments(n)
+	require.Len(header, 7)
+	for _, c := range header {
+		require.Equal(CommentsHeader, c.Properties[uast.InternalRoleKey])
+	}
+
+	// "# API documentation encoding:" documents the next import
+	var found bool
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		leading, _ := Comments(p.Node())
+		for _, c := range leading {
+			if strings.TrimSpace(c.Token) == "API documentation encoding:" {
+				require.Equal("ImportFrom", p.Node().InternalType)
+				found = true
+			}
+		}
+	}
+
+	require.True(found)
+}
+
+func TestCommentBinderNested(t *testing.T) {
+	require := require.New(t)
+
+	code := "def f():\n    # about x\n    x = 1  # one\n"
+
+	// def f():
+	//     x = 1
+	x := &uast.Node{
+		InternalType:  "Assign",
+		Properties:    map[string]string{},
+		StartPosition: &uast.Position{Line: 3, Col: 5},
+		Children: []*uast.Node{{
+			InternalType:  "Name",
+			Properties:    map[string]string{uast.InternalRoleKey: "targets"},
+			StartPosition: &uast.Position{Line: 3, Col: 5},
+			Children: []*uast.Node{{
+				InternalType:  "PreviousNoops",
+				Properties:    map[string]string{uast.InternalRoleKey: "noops_previous"},
+				StartPosition: &uast.Position{Line: 2, Col: 1},
+				Children: []*uast.Node{{
+					InternalType:  "NoopLine",
+					Properties:    map[string]string{uast.InternalRoleKey: "lines"},
+					Token:         " about x\n",
+					StartPosition: &uast.Position{Line: 2, Col: 1},
+				}},
+			}, {
+				InternalType:  "SameLineNoops",
+				Properties:    map[string]string{uast.InternalRoleKey: "noops_sameline"},
+				Token:         "[# one]",
+				StartPosition: &uast.Position{Line: 3, Col: 12},
+			}},
+		}},
+	}
+
+	f := &uast.Node{
+		InternalType:  "FunctionDef",
+		Properties:    map[string]string{uast.InternalRoleKey: "body"},
+		Token:         "f",
+		StartPosition: &uast.Position{Line: 1, Col: 1},
+		Children: []*uast.Node{{
+			InternalType: "FunctionDef.body",
+			Properties:   map[string]string{},
+			Children:     []*uast.Node{x},
+		}},
+	}
+
+	module := &uast.Node{InternalType: "Module", Children: []*uast.Node{f}}
+	err := NewCommentBinder().Do(code, protocol.UTF8, module)
+	require.NoError(err)
+
+	leading, trailing := Comments(x)
+	require.Equal([]string{"about x"}, tokens(leading))
+	require.Equal([]string{"[# one]"}, tokens(trailing))
+
+	leading, trailing = Comments(f)
+	require.Len(leading, 0)
+	require.Len(trailing, 0)
+}
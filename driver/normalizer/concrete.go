@@ -0,0 +1,180 @@
+package normalizer
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/bblfsh/python-driver/driver/pytoken"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Properties set by the ConcreteSyntax on the nodes owning a token.
+const (
+	// SourceTextKey is the token owned by a node, exactly as in the source.
+	SourceTextKey = "sourceText"
+	// LeadingTriviaKey is the source between the token of a node and the
+	// token before, from the line of its token.
+	LeadingTriviaKey = "leadingTrivia"
+	// TrailingTriviaKey is the source after the token of a node up to its
+	// first line break, included.
+	TrailingTriviaKey = "trailingTrivia"
+)
+
+// ConcreteSyntax is a `transformer.Tranformer` that attaches every byte of the
+// source to the UAST, so Regenerate can reproduce it. Each token of the source
+// starting where a node starts is owned by the deepest of them and stored in
+// its SourceTextKey property. The rest of the tokens, the trivia (whitespace,
+// comments and line breaks) and the ones not in the AST (like brackets and
+// keywords), are attached to the owner before them up to the first line break
+// and to the owner after them from there, in the TrailingTriviaKey and
+// LeadingTriviaKey properties. If no node owns a token, the whole source is
+// the leading trivia of the root.
+//
+// It needs the offsets filled by the Positioner. The source regenerated is the
+// source decoded to UTF-8, identical to the original file unless it had a
+// coding cookie for another encoding (see `pep263.Source`).
+type ConcreteSyntax struct{}
+
+// NewConcreteSyntax returns a new ConcreteSyntax.
+func NewConcreteSyntax() *ConcreteSyntax {
+	return &ConcreteSyntax{}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *ConcreteSyntax) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	starts := nodeStarts(n)
+	var (
+		owners []*uast.Node
+		// trivia holds the text before each owner and after the last one.
+		trivia []string
+		last   int
+	)
+
+	for _, tk := range pytoken.Tokenize(code) {
+		owner, ok := starts[uint32(tk.Offset)]
+		if !ok {
+			continue
+		}
+
+		setProperty(owner, SourceTextKey, tk.Text)
+		owners = append(owners, owner)
+		trivia = append(trivia, code[last:tk.Offset])
+		last = tk.End()
+	}
+
+	if len(owners) == 0 {
+		setProperty(n, SourceTextKey, "")
+		if code != "" {
+			setProperty(n, LeadingTriviaKey, code)
+		}
+
+		return nil
+	}
+
+	trivia = append(trivia, code[last:])
+	setProperty(owners[0], LeadingTriviaKey, trivia[0])
+	setProperty(owners[len(owners)-1], TrailingTriviaKey, trivia[len(trivia)-1])
+	for i := 1; i < len(owners); i++ {
+		trailing, leading := splitTrivia(trivia[i])
+		setProperty(owners[i-1], TrailingTriviaKey, trailing)
+		setProperty(owners[i], LeadingTriviaKey, leading)
+	}
+
+	return nil
+}
+
+// nodeStarts returns the deepest node starting at each offset, the first one
+// in pre-order between the ones as deep.
+func nodeStarts(n *uast.Node) map[uint32]*uast.Node {
+	starts := make(map[uint32]*uast.Node)
+	depths := make(map[uint32]int)
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for {
+		p := iter.Next()
+		if p.IsEmpty() {
+			break
+		}
+
+		pos := p.Node().StartPosition
+		if pos == nil {
+			continue
+		}
+
+		if d, ok := depths[pos.Offset]; !ok || len(p) > d {
+			starts[pos.Offset], depths[pos.Offset] = p.Node(), len(p)
+		}
+	}
+
+	return starts
+}
+
+// splitTrivia splits the trivia between two tokens after its first line
+// break.
+func splitTrivia(s string) (trailing, leading string) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\n':
+			return s[:i+1], s[i+1:]
+		case '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i++
+			}
+
+			return s[:i+1], s[i+1:]
+		}
+	}
+
+	return "", s
+}
+
+func setProperty(n *uast.Node, key, value string) {
+	if value == "" && key != SourceTextKey {
+		return
+	}
+
+	if n.Properties == nil {
+		n.Properties = make(map[string]string)
+	}
+
+	n.Properties[key] = value
+}
+
+// Regenerate returns the source of a UAST transformed by the ConcreteSyntax,
+// concatenating the trivia and tokens of its nodes by position.
+func Regenerate(n *uast.Node) string {
+	var owners []*uast.Node
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for {
+		p := iter.Next()
+		if p.IsEmpty() {
+			break
+		}
+
+		if _, ok := p.Node().Properties[SourceTextKey]; ok {
+			owners = append(owners, p.Node())
+		}
+	}
+
+	sort.SliceStable(owners, func(i, j int) bool {
+		return startOffset(owners[i]) < startOffset(owners[j])
+	})
+
+	var buf bytes.Buffer
+	for _, o := range owners {
+		buf.WriteString(o.Properties[LeadingTriviaKey])
+		buf.WriteString(o.Properties[SourceTextKey])
+		buf.WriteString(o.Properties[TrailingTriviaKey])
+	}
+
+	return buf.String()
+}
+
+func startOffset(n *uast.Node) uint32 {
+	if n.StartPosition == nil {
+		return 0
+	}
+
+	return n.StartPosition.Offset
+}
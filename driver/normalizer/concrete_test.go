@@ -0,0 +1,58 @@
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func TestConcreteSyntax(t *testing.T) {
+	require := require.New(t)
+
+	code, n, err := getDriverFixture("comments.py")
+	require.NoError(err)
+
+	for _, tr := range TransformersFor(ModeConcrete) {
+		require.NoError(tr.Do(code, protocol.UTF8, n))
+	}
+
+	require.Equal(code, Regenerate(n))
+
+	name, num := findType(n, "Name"), findType(n, "Num")
+	require.Equal("a", name.Properties[SourceTextKey])
+	require.NotContains(name.Properties, TrailingTriviaKey)
+	require.Equal(" = ", num.Properties[LeadingTriviaKey])
+	require.Equal("1", num.Properties[SourceTextKey])
+
+	// the noops of the line start at the whitespace before the comment
+	noops := findType(n, "SameLineNoops")
+	require.Equal(" ", noops.Properties[SourceTextKey])
+	require.Equal("# line trailing comment\n", noops.Properties[TrailingTriviaKey])
+}
+
+func findType(n *uast.Node, internalType string) *uast.Node {
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		if p.Node().InternalType == internalType {
+			return p.Node()
+		}
+	}
+
+	return nil
+}
+
+func TestConcreteSyntaxNoTokens(t *testing.T) {
+	require := require.New(t)
+
+	n := uast.NewNode()
+	code := "\n  \n"
+	require.NoError(NewConcreteSyntax().Do(code, protocol.UTF8, n))
+	require.Equal(code, n.Properties[LeadingTriviaKey])
+	require.Equal(code, Regenerate(n))
+
+	n = uast.NewNode()
+	require.NoError(NewConcreteSyntax().Do("", protocol.UTF8, n))
+	require.Equal("", Regenerate(n))
+}
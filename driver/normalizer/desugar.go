@@ -0,0 +1,550 @@
+package normalizer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// DesugaredKey is the property set by the Desugarer on the nodes it creates,
+// with the internal type of the node they replace. They have the positions of
+// that node, its span in the source.
+const DesugaredKey = "desugared"
+
+// Desugarer is a `transformer.Tranformer` that rewrites some constructs of
+// Python in terms of simpler ones, for the analyses that would rather handle
+// a single form of them:
+//
+// - The list, set and dict comprehensions are replaced by an accumulator
+// variable (_comp0, _comp1...) filled by explicit loops before the statement
+// using them. The generator expressions, and the comprehensions inside them,
+// in lambdas or in the test of a while, are left as they are.
+// - `x += y` becomes `x = x + y`.
+// - The decorators of functions and classes become an assignment after the
+// definition: `f = decorator(f)`.
+// - `with a as x: body` becomes `_with0 = a`, `x = _with0.__enter__()` and
+// `try: body finally: _with0.__exit__(None, None, None)`.
+// - `a < b < c` becomes `a < b and b < c`.
+//
+// The rewrites don't keep the exact semantics of the code: the middle operands
+// of the comparisons are evaluated twice, the exceptions are not passed to
+// __exit__ and the accumulators are variables of the enclosing scope. The
+// comments of a statement go to the first statement replacing it.
+//
+// It works on the UAST before the annotation, which annotates the new nodes
+// as the parsed ones.
+type Desugarer struct{}
+
+// NewDesugarer returns a new Desugarer.
+func NewDesugarer() *Desugarer {
+	return &Desugarer{}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *Desugarer) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	d := &desugarer{
+		py2:         n.Properties[PythonVersionKey] == "2",
+		temporaries: make(map[string]int),
+	}
+
+	d.walk(n)
+	return nil
+}
+
+type desugarer struct {
+	py2 bool
+	// temporaries is the number of variables created by prefix.
+	temporaries map[string]int
+}
+
+// temporary returns the name of a new variable.
+func (d *desugarer) temporary(prefix string) string {
+	i := d.temporaries[prefix]
+	d.temporaries[prefix]++
+	return prefix + strconv.Itoa(i)
+}
+
+func (d *desugarer) walk(n *uast.Node) {
+	children := make([]*uast.Node, 0, len(n.Children))
+	for _, c := range n.Children {
+		if isStatement(c, n) {
+			children = append(children, d.statement(c, n)...)
+			continue
+		}
+
+		c = d.expression(c)
+		d.walk(c)
+		children = append(children, c)
+	}
+
+	n.Children = children
+}
+
+// statement returns the desugared statements replacing s.
+func (d *desugarer) statement(s, parent *uast.Node) []*uast.Node {
+	var stmts []*uast.Node
+	for s.InternalType != "ExceptHandler" {
+		holder, i := findComprehension(s)
+		if holder == nil {
+			break
+		}
+
+		for _, p := range d.comprehension(holder, i) {
+			setRole(p, s.Properties[uast.InternalRoleKey])
+			stmts = append(stmts, d.statement(p, parent)...)
+		}
+	}
+
+	var replaced []*uast.Node
+	switch s.InternalType {
+	case "AugAssign":
+		if a := d.augAssign(s); a != nil {
+			replaced = []*uast.Node{a}
+		}
+	case "With":
+		replaced = d.with(s)
+	case "FunctionDef", "AsyncFunctionDef", "ClassDef":
+		replaced = d.decorators(s)
+	}
+
+	if replaced == nil {
+		d.walk(s)
+		stmts = append(stmts, s)
+	} else {
+		for _, r := range replaced {
+			setRole(r, s.Properties[uast.InternalRoleKey])
+			stmts = append(stmts, d.statement(r, parent)...)
+		}
+	}
+
+	if len(stmts) > 0 && stmts[0] != s {
+		moveTrivia(s, stmts[0])
+	}
+
+	return stmts
+}
+
+// expression returns the node replacing an expression.
+func (d *desugarer) expression(n *uast.Node) *uast.Node {
+	if n.InternalType != "Compare" {
+		return n
+	}
+
+	left := field(n, "left")
+	ops := promotedList(n, "ops")
+	comparators := promotedList(n, "comparators")
+	if left == nil || len(ops) < 2 || len(ops) != len(comparators) {
+		return n
+	}
+
+	var values []*uast.Node
+	for i, op := range ops {
+		c := d.node(n, "Compare", "",
+			setRole(left, "left"),
+			d.promoted(n, "Compare.ops", op),
+			d.promoted(n, "Compare.comparators", comparators[i]),
+		)
+
+		values = append(values, setRole(c, "values"))
+		left = clone(comparators[i])
+	}
+
+	and := setRole(d.node(n, "And", ""), "op")
+	b := d.node(n, "BoolOp", "", append([]*uast.Node{and}, values...)...)
+	moveTrivia(n, b)
+	return setRole(b, n.Properties[uast.InternalRoleKey])
+}
+
+// augAssign returns the Assign replacing an AugAssign, or nil if it lacks a
+// field.
+func (d *desugarer) augAssign(s *uast.Node) *uast.Node {
+	target, op, value := field(s, "target"), field(s, "op"), field(s, "value")
+	if target == nil || op == nil || value == nil {
+		return nil
+	}
+
+	left := load(clone(target))
+	bin := d.node(s, "BinOp", "",
+		setRole(left, "left"),
+		op,
+		setRole(value, "right"),
+	)
+
+	return d.node(s, "Assign", "", setRole(target, "targets"), setRole(bin, "value"))
+}
+
+// decorators returns the definition s without its decorators followed by the
+// assignment applying them, or nil if it has none.
+func (d *desugarer) decorators(s *uast.Node) []*uast.Node {
+	var decorators []*uast.Node
+	children := s.Children[:0]
+	for _, c := range s.Children {
+		if c.InternalType == s.InternalType+".decorator_list" {
+			decorators = c.Children
+			continue
+		}
+
+		children = append(children, c)
+	}
+
+	s.Children = children
+	if len(decorators) == 0 {
+		return nil
+	}
+
+	value := d.name(s, s.Token, "Load")
+	for i := len(decorators) - 1; i >= 0; i-- {
+		value = d.call(s, decorators[i], value)
+	}
+
+	assign := d.node(s, "Assign", "",
+		setRole(d.name(s, s.Token, "Store"), "targets"),
+		setRole(value, "value"),
+	)
+
+	return []*uast.Node{s, assign}
+}
+
+// with returns the statements replacing a With, nested for each of its items.
+func (d *desugarer) with(s *uast.Node) []*uast.Node {
+	items := promotedList(s, "items")
+	if d.py2 {
+		// the only item is in the With itself
+		items = []*uast.Node{s}
+	}
+
+	body := promotedList(s, "body")
+	for i := len(items) - 1; i >= 0; i-- {
+		body = d.withItem(s, items[i], body)
+	}
+
+	return body
+}
+
+func (d *desugarer) withItem(s, item *uast.Node, body []*uast.Node) []*uast.Node {
+	expr := field(item, "context_expr")
+	if expr == nil {
+		return body
+	}
+
+	mgr := d.temporary("_with")
+	assign := d.node(s, "Assign", "",
+		setRole(d.name(s, mgr, "Store"), "targets"),
+		setRole(expr, "value"),
+	)
+
+	exit := d.call(s, d.attribute(s, d.name(s, mgr, "Load"), "__exit__"), d.none(s), d.none(s), d.none(s))
+	enter := d.call(s, d.attribute(s, d.name(s, mgr, "Load"), "__enter__"))
+
+	var first *uast.Node
+	if target := field(item, "optional_vars"); target != nil {
+		first = d.node(s, "Assign", "", setRole(target, "targets"), setRole(enter, "value"))
+	} else {
+		first = d.node(s, "Expr", "", setRole(enter, "value"))
+	}
+
+	try := "Try"
+	if d.py2 {
+		try = "TryFinally"
+	}
+
+	exitStmt := d.node(s, "Expr", "", setRole(exit, "value"))
+	return []*uast.Node{assign, first, d.node(s, try, "",
+		d.promoted(s, try+".body", body...),
+		d.promoted(s, try+".finalbody", exitStmt),
+	)}
+}
+
+// comprehensions are the comprehensions replaced by an accumulator.
+var comprehensions = map[string]bool{"ListComp": true, "SetComp": true, "DictComp": true}
+
+// findComprehension returns the parent of the first comprehension in the
+// expressions of s and its index, or nil if it has none. The expressions that
+// aren't always evaluated once before s, as the branches of an IfExp or the
+// operands of a BoolOp after the first, are skipped.
+func findComprehension(s *uast.Node) (*uast.Node, int) {
+	operands := 0
+	for i, c := range s.Children {
+		if isStatement(c, s) {
+			continue
+		}
+
+		role := c.Properties[uast.InternalRoleKey]
+		if s.InternalType == "BoolOp" && role == "values" {
+			operands++
+		}
+
+		switch {
+		case s.InternalType == "While" && role == "test",
+			s.InternalType == "IfExp" && role != "test",
+			s.InternalType == "BoolOp" && operands > 1:
+			continue
+		}
+
+		if comprehensions[c.InternalType] {
+			return s, i
+		}
+
+		switch c.InternalType {
+		case "GeneratorExp", "Lambda":
+			continue
+		}
+
+		if holder, j := findComprehension(c); holder != nil {
+			return holder, j
+		}
+	}
+
+	return nil, 0
+}
+
+// comprehension replaces the comprehension at the index i of the children of
+// holder by a new accumulator, returning the statements filling it.
+func (d *desugarer) comprehension(holder *uast.Node, i int) []*uast.Node {
+	comp := holder.Children[i]
+	acc := d.temporary("_comp")
+
+	var init, add *uast.Node
+	switch comp.InternalType {
+	case "ListComp":
+		init = d.node(comp, "List", "")
+		init.Properties["ctx"] = "Load"
+		add = d.addCall(comp, acc, "append", field(comp, "elt"))
+	case "SetComp":
+		init = d.call(comp, d.name(comp, "set", "Load"))
+		add = d.addCall(comp, acc, "add", field(comp, "elt"))
+	case "DictComp":
+		init = d.node(comp, "Dict", "")
+		key, value := field(comp, "key"), field(comp, "value")
+		index := d.node(comp, "Index", "", setRole(key, "value"))
+		target := d.node(comp, "Subscript", "",
+			setRole(index, "slice"),
+			setRole(d.name(comp, acc, "Load"), "value"),
+		)
+
+		target.Properties["ctx"] = "Store"
+		add = d.node(comp, "Assign", "",
+			setRole(target, "targets"),
+			setRole(value, "value"),
+		)
+	}
+
+	stmt := add
+	generators := promotedList(comp, "generators")
+	for j := len(generators) - 1; j >= 0; j-- {
+		g := generators[j]
+		ifs := promotedList(g, "ifs")
+		for k := len(ifs) - 1; k >= 0; k-- {
+			stmt = d.node(comp, "If", "if",
+				d.promoted(comp, "If.body", stmt),
+				setRole(ifs[k], "test"),
+			)
+		}
+
+		typ, token := "For", "for"
+		if g.Properties["is_async"] == "1" {
+			typ, token = "AsyncFor", ""
+		}
+
+		stmt = d.node(comp, typ, token,
+			d.promoted(comp, typ+".body", stmt),
+			field(g, "iter"),
+			field(g, "target"),
+		)
+	}
+
+	holder.Children[i] = setRole(d.name(comp, acc, "Load"), comp.Properties[uast.InternalRoleKey])
+	assign := d.node(comp, "Assign", "",
+		setRole(d.name(comp, acc, "Store"), "targets"),
+		setRole(init, "value"),
+	)
+
+	return []*uast.Node{assign, stmt}
+}
+
+// addCall returns the statement calling the method of an accumulator adding
+// an element.
+func (d *desugarer) addCall(orig *uast.Node, acc, method string, elt *uast.Node) *uast.Node {
+	call := d.call(orig, d.attribute(orig, d.name(orig, acc, "Load"), method), elt)
+	return d.node(orig, "Expr", "", setRole(call, "value"))
+}
+
+// node returns a node created from orig, with its positions. Its children are
+// sorted by their field, as the ones converted by ToNode.
+func (d *desugarer) node(orig *uast.Node, typ, token string, children ...*uast.Node) *uast.Node {
+	n := &uast.Node{
+		InternalType: typ,
+		Token:        token,
+		Properties:   map[string]string{DesugaredKey: orig.InternalType},
+	}
+
+	if orig.StartPosition != nil {
+		pos := *orig.StartPosition
+		n.StartPosition = &pos
+	}
+
+	if orig.EndPosition != nil {
+		pos := *orig.EndPosition
+		n.EndPosition = &pos
+	}
+
+	for _, c := range children {
+		if c != nil {
+			n.Children = append(n.Children, c)
+		}
+	}
+
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		return fieldName(n.Children[i]) < fieldName(n.Children[j])
+	})
+
+	return n
+}
+
+// promoted returns the node of a promoted list field, or nil if it's empty.
+func (d *desugarer) promoted(orig *uast.Node, typ string, elements ...*uast.Node) *uast.Node {
+	if len(elements) == 0 {
+		return nil
+	}
+
+	for _, e := range elements {
+		setRole(e, "")
+	}
+
+	return &uast.Node{
+		InternalType: typ,
+		Properties:   map[string]string{"promotedPropertyList": "true"},
+		Children:     elements,
+	}
+}
+
+func (d *desugarer) name(orig *uast.Node, id, ctx string) *uast.Node {
+	n := d.node(orig, "Name", id)
+	n.Properties["ctx"] = ctx
+	return n
+}
+
+func (d *desugarer) attribute(orig, value *uast.Node, attr string) *uast.Node {
+	n := d.node(orig, "Attribute", attr, setRole(value, "value"))
+	n.Properties["ctx"] = "Load"
+	return n
+}
+
+func (d *desugarer) call(orig, fn *uast.Node, args ...*uast.Node) *uast.Node {
+	children := []*uast.Node{setRole(fn, "func")}
+	for _, a := range args {
+		children = append(children, setRole(a, "args"))
+	}
+
+	return d.node(orig, "Call", "", children...)
+}
+
+// none returns None, a name in Python 2.
+func (d *desugarer) none(orig *uast.Node) *uast.Node {
+	if d.py2 {
+		return d.name(orig, "None", "Load")
+	}
+
+	return d.node(orig, "NoneLiteral", "None")
+}
+
+// fieldName returns the field of the native AST holding a node.
+func fieldName(n *uast.Node) string {
+	if n.Properties["promotedPropertyList"] == "true" {
+		return n.InternalType[strings.LastIndex(n.InternalType, ".")+1:]
+	}
+
+	return n.Properties[uast.InternalRoleKey]
+}
+
+// field returns the child of n for a single field.
+func field(n *uast.Node, name string) *uast.Node {
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] == name {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// promotedList returns the elements of the promoted list field of n.
+func promotedList(n *uast.Node, name string) []*uast.Node {
+	for _, c := range n.Children {
+		if c.InternalType == n.InternalType+"."+name {
+			return c.Children
+		}
+	}
+
+	return nil
+}
+
+// setRole sets the internal role of n, removing it if empty.
+func setRole(n *uast.Node, role string) *uast.Node {
+	if n == nil {
+		return nil
+	}
+
+	if role == "" {
+		delete(n.Properties, uast.InternalRoleKey)
+		return n
+	}
+
+	if n.Properties == nil {
+		n.Properties = make(map[string]string)
+	}
+
+	n.Properties[uast.InternalRoleKey] = role
+	return n
+}
+
+// clone returns a deep copy of an expression, without its comments.
+func clone(n *uast.Node) *uast.Node {
+	c := *n
+	c.Properties = make(map[string]string, len(n.Properties))
+	for k, v := range n.Properties {
+		c.Properties[k] = v
+	}
+
+	c.Roles = append([]uast.Role(nil), n.Roles...)
+	c.Children = nil
+	for _, ch := range n.Children {
+		if !isTrivia(ch) {
+			c.Children = append(c.Children, clone(ch))
+		}
+	}
+
+	return &c
+}
+
+// load sets the Load context of the target of an AugAssign: a name, an
+// attribute or a subscript.
+func load(n *uast.Node) *uast.Node {
+	if _, ok := n.Properties["ctx"]; ok {
+		n.Properties["ctx"] = "Load"
+	}
+
+	return n
+}
+
+// moveTrivia moves the comments and noops of from to to.
+func moveTrivia(from, to *uast.Node) {
+	if from == to {
+		return
+	}
+
+	children := from.Children[:0]
+	for _, c := range from.Children {
+		if isTrivia(c) {
+			to.Children = append(to.Children, c)
+			continue
+		}
+
+		children = append(children, c)
+	}
+
+	from.Children = children
+}
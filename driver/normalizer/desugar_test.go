@@ -0,0 +1,281 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func desugar(t *testing.T, name string) *uast.Node {
+	require := require.New(t)
+
+	code, n, err := getDriverFixture(name)
+	require.NoError(err)
+
+	for _, tr := range TransformersFor(ModeDesugar) {
+		require.NoError(tr.Do(code, protocol.UTF8, n))
+	}
+
+	return n
+}
+
+func desugarNative(t *testing.T, code, native string) *uast.Node {
+	require := require.New(t)
+
+	var obj map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(native), &obj))
+
+	n, err := ToNode.ToNode(obj)
+	require.NoError(err)
+
+	for _, tr := range TransformersFor(ModeDesugar) {
+		require.NoError(tr.Do(code, protocol.UTF8, n))
+	}
+
+	return n
+}
+
+func TestDesugarAugAssign(t *testing.T) {
+	require := require.New(t)
+
+	// a += 1
+	n := desugar(t, "augassign.py")
+	require.Len(n.Children, 4)
+
+	assign := n.Children[0]
+	require.Equal("Assign", assign.InternalType)
+	require.Equal("AugAssign", assign.Properties[DesugaredKey])
+	require.Equal("body", assign.Properties[uast.InternalRoleKey])
+	require.Contains(assign.Roles, uast.Assignment)
+	// the span of the AugAssign is its operator
+	require.Equal(uint32(2), assign.StartPosition.Offset)
+	require.Equal(uint32(3), assign.StartPosition.Col)
+
+	target := child(assign, "targets")
+	require.Equal("a", target.Token)
+	require.Equal("Store", target.Properties["ctx"])
+
+	bin := child(assign, "value")
+	require.Equal("BinOp", bin.InternalType)
+	require.Contains(bin.Roles, uast.Binary)
+	require.Equal("Load", child(bin, "left").Properties["ctx"])
+	require.Equal("Add", child(bin, "op").InternalType)
+	require.Equal("1", child(bin, "right").Token)
+}
+
+// pragmaCode has a pragma on an AugAssign.
+const pragmaCode = "x += 1  # noqa\n"
+
+const pragmaNative = `{"PY3AST": {"ast_type": "Module", "body": [{
+    "ast_type": "AugAssign", "lineno": 1, "col_offset": 1,
+    "target": {"ast_type": "Name", "id": "x", "ctx": "Store", "lineno": 1, "col_offset": 1,
+      "noops_sameline": {"ast_type": "SameLineNoops", "lineno": 1, "col_offset": 9,
+        "end_lineno": 1, "end_col_offset": 14, "noop_line": ["# noqa"]}},
+    "op": {"ast_type": "Add"},
+    "value": {"ast_type": "Num", "n": 1, "lineno": 1, "col_offset": 6}
+  }]}}`
+
+func TestDesugarPragma(t *testing.T) {
+	require := require.New(t)
+
+	n := desugarNative(t, pragmaCode, pragmaNative)
+	require.Len(n.Children, 1)
+
+	assign := n.Children[0]
+	require.Equal("Assign", assign.InternalType)
+	pragma := findType(assign, Pragma)
+	require.NotNil(pragma)
+	require.Equal("flake8", pragma.Properties[ToolKey])
+}
+
+func TestDesugarCompare(t *testing.T) {
+	require := require.New(t)
+
+	// var4 < var5 < var6
+	n := desugar(t, "issue97_lessThan.py")
+	b := child(n.Children[0], "value")
+	require.Equal("BoolOp", b.InternalType)
+	require.Equal("Compare", b.Properties[DesugaredKey])
+	require.Equal("And", child(b, "op").InternalType)
+
+	var operands []string
+	for _, c := range b.Children {
+		if c.InternalType != "Compare" {
+			continue
+		}
+
+		require.Contains(c.Roles, uast.Binary)
+		left := child(c, "left")
+		right := findType(c, "Compare.comparators").Children[0]
+		operands = append(operands, left.Token, right.Token)
+	}
+
+	require.Equal([]string{"var4", "var5", "var5", "var6"}, operands)
+}
+
+func TestDesugarDecorators(t *testing.T) {
+	require := require.New(t)
+
+	// @testtag2(1, 2)
+	// @testtag3
+	// def testfnc2(): ...
+	n := desugar(t, "u2_func_tagged.py")
+	require.Len(n.Children, 4)
+	require.Nil(findType(n, "FunctionDef.decorator_list"))
+
+	def, assign := n.Children[2], n.Children[3]
+	require.Equal("testfnc2", def.Token)
+	require.Equal("Assign", assign.InternalType)
+	require.Equal("FunctionDef", assign.Properties[DesugaredKey])
+	require.Equal(def.StartPosition, assign.StartPosition)
+	require.Equal("testfnc2", child(assign, "targets").Token)
+
+	outer := child(assign, "value")
+	require.Equal("Call", outer.InternalType)
+	require.Equal("testtag2", child(child(outer, "func"), "func").Token)
+	inner := child(outer, "args")
+	require.Equal("testtag3", child(inner, "func").Token)
+	require.Equal("testfnc2", child(inner, "args").Token)
+}
+
+func TestDesugarWith(t *testing.T) {
+	require := require.New(t)
+
+	// with something as s:
+	//     a = s
+	n := desugar(t, "with.py")
+	require.Len(n.Children, 3)
+
+	mgr, enter, try := n.Children[0], n.Children[1], n.Children[2]
+	require.Equal("_with0", child(mgr, "targets").Token)
+	require.Equal("something", child(mgr, "value").Token)
+	require.Equal("s", child(enter, "targets").Token)
+	require.Equal("__enter__", child(child(enter, "value"), "func").Token)
+
+	require.Equal("Try", try.InternalType)
+	require.Contains(try.Roles, uast.Try)
+	require.Equal("Assign", findType(try, "Try.body").Children[0].InternalType)
+
+	exit := child(findType(try, "Try.finalbody").Children[0], "value")
+	require.Equal("__exit__", child(exit, "func").Token)
+	require.Len(exit.Children, 4)
+}
+
+func TestDesugarComprehension(t *testing.T) {
+	require := require.New(t)
+
+	// [i*2 for i in somelist if i > 2]
+	n := desugar(t, "comprehension_list.py")
+	require.Nil(findType(n, "ListComp"))
+
+	init, loop, expr := n.Children[0], n.Children[1], n.Children[2]
+	require.Equal("_comp0", child(init, "targets").Token)
+	require.Equal("List", child(init, "value").InternalType)
+
+	require.Equal("For", loop.InternalType)
+	require.Equal("ListComp", loop.Properties[DesugaredKey])
+	require.Contains(loop.Roles, uast.For)
+	require.Equal("i", child(loop, "target").Token)
+	require.Equal("somelist", child(loop, "iter").Token)
+
+	cond := findType(loop, "For.body").Children[0]
+	require.Equal("If", cond.InternalType)
+	require.Equal("Compare", child(cond, "test").InternalType)
+
+	add := child(findType(cond, "If.body").Children[0], "value")
+	require.Equal("append", child(add, "func").Token)
+	require.Equal("BinOp", child(add, "args").InternalType)
+
+	require.Equal("_comp0", child(expr, "value").Token)
+
+	// the nested loops of the second one
+	require.Equal("_comp1", child(n.Children[3], "targets").Token)
+	outer := n.Children[4]
+	require.Equal("For", findType(outer, "For.body").Children[0].InternalType)
+}
+
+func TestDesugarDictComprehension(t *testing.T) {
+	require := require.New(t)
+
+	// {n: n*2 for n in somelist if n>2}
+	n := desugar(t, "comprehension_dict.py")
+	set := findType(n, "Subscript")
+	require.Equal("Store", set.Properties["ctx"])
+	require.Equal("_comp0", child(set, "value").Token)
+	require.Equal("n", child(child(set, "slice"), "value").Token)
+
+	assign := findType(n, "If.body").Children[0]
+	require.Equal("BinOp", child(assign, "value").InternalType)
+}
+
+// conditionalCode has comprehensions evaluated only under a condition.
+const conditionalCode = `x = [y for y in z] if ok else None
+def f(cache, keys):
+    return cache or [load(k) for k in keys]
+`
+
+var conditionalNative = `{"PY3AST": {"ast_type": "Module", "body": [{
+    "ast_type": "Assign", "lineno": 1, "col_offset": 1,
+    "targets": [{"ast_type": "Name", "id": "x", "ctx": "Store", "lineno": 1, "col_offset": 1}],
+    "value": {"ast_type": "IfExp", "lineno": 1, "col_offset": 5,
+      "test": {"ast_type": "Name", "id": "ok", "ctx": "Load", "lineno": 1, "col_offset": 23},
+      "body": {"ast_type": "ListComp", "lineno": 1, "col_offset": 5,
+        "elt": {"ast_type": "Name", "id": "y", "ctx": "Load", "lineno": 1, "col_offset": 6},
+        "generators": [{"ast_type": "comprehension", "ifs": [], "is_async": 0,
+          "target": {"ast_type": "Name", "id": "y", "ctx": "Store", "lineno": 1, "col_offset": 12},
+          "iter": {"ast_type": "Name", "id": "z", "ctx": "Load", "lineno": 1, "col_offset": 17}}]},
+      "orelse": {"ast_type": "NoneLiteral", "LiteralValue": "None", "lineno": 1, "col_offset": 31}}
+  }, {
+    "ast_type": "FunctionDef", "name": "f", "lineno": 2, "col_offset": 1,
+    "args": {"ast_type": "arguments",
+      "args": [
+        {"ast_type": "arg", "arg": "cache", "lineno": 2, "col_offset": 7},
+        {"ast_type": "arg", "arg": "keys", "lineno": 2, "col_offset": 14}
+      ],
+      "defaults": [], "kw_defaults": [], "kwonlyargs": []},
+    "body": [{"ast_type": "Return", "lineno": 3, "col_offset": 5,
+      "value": {"ast_type": "BoolOp", "lineno": 3, "col_offset": 12,
+        "op": {"ast_type": "Or"},
+        "values": [
+          {"ast_type": "Name", "id": "cache", "ctx": "Load", "lineno": 3, "col_offset": 12},
+          {"ast_type": "ListComp", "lineno": 3, "col_offset": 21,
+            "elt": {"ast_type": "Call", "lineno": 3, "col_offset": 22,
+              "func": {"ast_type": "Name", "id": "load", "ctx": "Load", "lineno": 3, "col_offset": 22},
+              "args": [{"ast_type": "Name", "id": "k", "ctx": "Load", "lineno": 3, "col_offset": 27}],
+              "keywords": []},
+            "generators": [{"ast_type": "comprehension", "ifs": [], "is_async": 0,
+              "target": {"ast_type": "Name", "id": "k", "ctx": "Store", "lineno": 3, "col_offset": 35},
+              "iter": {"ast_type": "Name", "id": "keys", "ctx": "Load", "lineno": 3, "col_offset": 40}}]}
+        ]}
+    }],
+    "decorator_list": []
+  }]}}`
+
+func TestDesugarConditionalComprehension(t *testing.T) {
+	require := require.New(t)
+
+	n := desugarNative(t, conditionalCode, conditionalNative)
+	require.Len(n.Children, 2)
+
+	assign := n.Children[0]
+	require.Equal("Assign", assign.InternalType)
+	require.Equal("ListComp", child(child(assign, "value"), "body").InternalType)
+
+	body := findType(n.Children[1], "FunctionDef.body")
+	require.Len(body.Children, 1)
+	ret := body.Children[0]
+	require.Equal("Return", ret.InternalType)
+	require.NotNil(findType(ret, "ListComp"))
+}
+
+func TestParseModeDesugar(t *testing.T) {
+	require := require.New(t)
+
+	m, err := ParseMode("desugar")
+	require.NoError(err)
+	require.Equal(ModeDesugar, m)
+	require.Equal("desugar", m.String())
+}
@@ -0,0 +1,186 @@
+package normalizer
+
+import (
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// ForwardReference is the internal role of the expressions parsed from the
+// string annotations by the ForwardRefParser.
+const ForwardReference = "forward_reference"
+
+// ForwardRefParser is a `transformer.Tranformer` that parses the string
+// literals in annotation position (forward references, as in
+// `def f(x: "Optional[Node]") -> "List[Node]"`) and adds the resulting
+// expression as a child of the Str node, with the ForwardReference internal
+// role. Strings nested in forward references are parsed too, except the
+// values of Literal and the metadata of Annotated.
+//
+// Strings whose content can't be mapped to the source (those with escape
+// sequences, split in several lines or implicitly concatenated) are left as
+// they are.
+type ForwardRefParser struct{}
+
+// NewForwardRefParser returns a new ForwardRefParser.
+func NewForwardRefParser() *ForwardRefParser {
+	return &ForwardRefParser{}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *ForwardRefParser) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	b := &binder{code: code, idx: newLineIndex([]byte(code))}
+
+	var annotations []*uast.Node
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		switch p.Node().Properties[uast.InternalRoleKey] {
+		case "annotation", "returns":
+			annotations = append(annotations, p.Node())
+		}
+	}
+
+	for _, a := range annotations {
+		b.parseForwardRefs(a)
+	}
+
+	return nil
+}
+
+func (b *binder) parseForwardRefs(n *uast.Node) {
+	if n.InternalType == "Subscript" {
+		b.parseSubscriptRefs(n)
+		return
+	}
+
+	if n.InternalType != "Str" {
+		for _, c := range n.Children {
+			b.parseForwardRefs(c)
+		}
+
+		return
+	}
+
+	if hasInternalRole(n, ForwardReference) {
+		return
+	}
+
+	c := b.stringContent(n)
+	if c == nil {
+		return
+	}
+
+	t := parseType(c)
+	if t == nil {
+		return
+	}
+
+	t.Properties[uast.InternalRoleKey] = ForwardReference
+	n.Children = append(n.Children, t)
+	b.parseForwardRefs(t)
+}
+
+// parseSubscriptRefs parses the forward references of a subscript, skipping
+// the values of Literal and the metadata of Annotated, which aren't types.
+func (b *binder) parseSubscriptRefs(n *uast.Node) {
+	var form string
+	if value := field(n, "value"); value != nil {
+		form = typingForm(value)
+	}
+
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] != "slice" {
+			b.parseForwardRefs(c)
+			continue
+		}
+
+		switch form {
+		case "Literal":
+		case "Annotated":
+			if args := subscriptArgs(c); len(args) > 0 {
+				b.parseForwardRefs(args[0])
+			}
+		default:
+			b.parseForwardRefs(c)
+		}
+	}
+}
+
+// typingModules are the modules whose special forms are recognized by
+// typingForm.
+var typingModules = []string{"typing", "typing_extensions"}
+
+// typingForm returns the name of a Name node, or of an Attribute of one of the
+// typingModules, as the special forms of typing are used.
+func typingForm(n *uast.Node) string {
+	switch n.InternalType {
+	case "Name":
+		return n.Token
+	case "Attribute":
+		if value := field(n, "value"); value != nil && value.InternalType == "Name" {
+			for _, m := range typingModules {
+				if value.Token == m {
+					return n.Token
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// subscriptArgs returns the arguments of a subscript from its slice.
+func subscriptArgs(slice *uast.Node) []*uast.Node {
+	// Python < 3.9 wraps the subscript in an Index node
+	if slice.InternalType == "Index" {
+		if slice = field(slice, "value"); slice == nil {
+			return nil
+		}
+	}
+
+	if slice.InternalType != "Tuple" {
+		return []*uast.Node{slice}
+	}
+
+	var args []*uast.Node
+	for _, c := range slice.Children {
+		if c.Properties[uast.InternalRoleKey] == "elts" {
+			args = append(args, c)
+		}
+	}
+
+	return args
+}
+
+// stringContent returns the content of a Str node and its position in the
+// source, if it's found there verbatim.
+func (b *binder) stringContent(n *uast.Node) *sourceText {
+	if n.StartPosition == nil || n.StartPosition.Col == 0 {
+		return nil
+	}
+
+	l := line(n)
+	src := b.idx.Line(b.code, l)
+	col := int(n.StartPosition.Col) - 1
+	if col >= len(src) {
+		return nil
+	}
+
+	rest := strings.TrimLeft(src[col:], "rRuUbBfF")
+	for _, q := range []string{`"""`, `'''`, `"`, `'`} {
+		if !strings.HasPrefix(rest, q) {
+			continue
+		}
+
+		content := rest[len(q):]
+		if !strings.HasPrefix(content, n.Token+q) {
+			return nil
+		}
+
+		start := len(src) - len(content)
+		return &sourceText{text: n.Token, line: l, col: start + 1}
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+const forwardRefsCode = `def f(x: "Optional[Node]") -> r'List["Node"]':
+    pass
+`
+
+// forwardRefsNative is the native AST of forwardRefsCode.
+const forwardRefsNative = `{"PY3AST": {
+  "ast_type": "Module",
+  "body": [{
+    "ast_type": "FunctionDef", "name": "f", "lineno": 1, "col_offset": 5,
+    "args": {"ast_type": "arguments",
+      "args": [{"ast_type": "arg", "arg": "x", "lineno": 1, "col_offset": 7,
+        "annotation": {"ast_type": "Str", "s": "Optional[Node]",
+          "lineno": 1, "col_offset": 10, "end_lineno": 1, "end_col_offset": 25}}],
+      "defaults": [], "kw_defaults": [], "kwonlyargs": []},
+    "returns": {"ast_type": "Str", "s": "List[\"Node\"]",
+      "lineno": 1, "col_offset": 31, "end_lineno": 1, "end_col_offset": 45},
+    "body": [{"ast_type": "Pass", "lineno": 2, "col_offset": 5}],
+    "decorator_list": []
+  }]
+}}`
+
+func TestForwardRefParser(t *testing.T) {
+	require := require.New(t)
+
+	var obj map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(forwardRefsNative), &obj))
+
+	n, err := ToNode.ToNode(obj)
+	require.NoError(err)
+	require.NoError(NewForwardRefParser().Do(forwardRefsCode, protocol.UTF8, n))
+	require.NoError(AnnotationRules.Apply(n))
+
+	f := n.Children[0]
+	x := arguments(child(f, "args"))[0]
+
+	// x: "Optional[Node]"
+	ref := child(child(x, "annotation"), ForwardReference)
+	require.NotNil(ref)
+	require.Equal("Subscript", ref.InternalType)
+	require.Contains(ref.Roles, uast.Annotation)
+	require.Equal(uast.Position{Line: 1, Col: 11}, *ref.StartPosition)
+
+	node := child(child(ref, "slice"), "value")
+	require.Equal("Node", node.Token)
+	require.Equal(uast.Position{Line: 1, Col: 20}, *node.StartPosition)
+
+	// -> r'List["Node"]', with a nested forward reference
+	ref = child(child(f, "returns"), ForwardReference)
+	require.NotNil(ref)
+	nested := child(child(ref, "slice"), "value")
+	require.Equal("Str", nested.InternalType)
+	node = child(nested, ForwardReference)
+	require.NotNil(node)
+	require.Equal("Name", node.InternalType)
+	require.Equal(uast.Position{Line: 1, Col: 39}, *node.StartPosition)
+}
+
+func TestForwardRefParserEscaped(t *testing.T) {
+	require := require.New(t)
+
+	code := "x: 'A\\x42' = None\n"
+	str := &uast.Node{
+		InternalType:  "Str",
+		Properties:    map[string]string{uast.InternalRoleKey: "annotation"},
+		Token:         "AB",
+		StartPosition: &uast.Position{Line: 1, Col: 4},
+	}
+
+	module := &uast.Node{InternalType: "Module", Children: []*uast.Node{str}}
+	require.NoError(NewForwardRefParser().Do(code, protocol.UTF8, module))
+	require.Len(str.Children, 0)
+}
+
+const forwardRefsValuesCode = `def f(x: Literal["foo"], y: typing.Literal["bar"]) -> Annotated["Node", "meta"]:
+    pass
+`
+
+// forwardRefsValuesNative is the native AST of forwardRefsValuesCode.
+const forwardRefsValuesNative = `{"PY3AST": {
+  "ast_type": "Module",
+  "body": [{
+    "ast_type": "FunctionDef", "name": "f", "lineno": 1, "col_offset": 5,
+    "args": {"ast_type": "arguments",
+      "args": [{"ast_type": "arg", "arg": "x", "lineno": 1, "col_offset": 7,
+        "annotation": {"ast_type": "Subscript", "ctx": "Load", "lineno": 1, "col_offset": 10,
+          "value": {"ast_type": "Name", "id": "Literal", "ctx": "Load", "lineno": 1, "col_offset": 10},
+          "slice": {"ast_type": "Index",
+            "value": {"ast_type": "Str", "s": "foo", "lineno": 1, "col_offset": 18}}}},
+        {"ast_type": "arg", "arg": "y", "lineno": 1, "col_offset": 26,
+        "annotation": {"ast_type": "Subscript", "ctx": "Load", "lineno": 1, "col_offset": 29,
+          "value": {"ast_type": "Attribute", "attr": "Literal", "ctx": "Load", "lineno": 1, "col_offset": 29,
+            "value": {"ast_type": "Name", "id": "typing", "ctx": "Load", "lineno": 1, "col_offset": 29}},
+          "slice": {"ast_type": "Index",
+            "value": {"ast_type": "Str", "s": "bar", "lineno": 1, "col_offset": 44}}}}],
+      "defaults": [], "kw_defaults": [], "kwonlyargs": []},
+    "returns": {"ast_type": "Subscript", "ctx": "Load", "lineno": 1, "col_offset": 55,
+      "value": {"ast_type": "Name", "id": "Annotated", "ctx": "Load", "lineno": 1, "col_offset": 55},
+      "slice": {"ast_type": "Index",
+        "value": {"ast_type": "Tuple", "ctx": "Load", "lineno": 1, "col_offset": 65, "elts": [
+          {"ast_type": "Str", "s": "Node", "lineno": 1, "col_offset": 65},
+          {"ast_type": "Str", "s": "meta", "lineno": 1, "col_offset": 73}]}}},
+    "body": [{"ast_type": "Pass", "lineno": 2, "col_offset": 5}],
+    "decorator_list": []
+  }]
+}}`
+
+func TestForwardRefParserValues(t *testing.T) {
+	require := require.New(t)
+
+	var obj map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(forwardRefsValuesNative), &obj))
+
+	n, err := ToNode.ToNode(obj)
+	require.NoError(err)
+	require.NoError(NewForwardRefParser().Do(forwardRefsValuesCode, protocol.UTF8, n))
+
+	f := n.Children[0]
+	args := arguments(child(f, "args"))
+	require.Len(args, 2)
+
+	// the values of Literal and typing.Literal
+	for _, a := range args {
+		value := child(child(child(a, "annotation"), "slice"), "value")
+		require.Equal("Str", value.InternalType)
+		require.Nil(child(value, ForwardReference), value.Token)
+	}
+
+	// the type of Annotated, but not its metadata
+	elts := child(child(child(f, "returns"), "slice"), "value").Children
+	require.Len(elts, 2)
+	ref := child(elts[0], ForwardReference)
+	require.NotNil(ref)
+	require.Equal("Node", ref.Token)
+	require.Nil(child(elts[1], ForwardReference))
+}
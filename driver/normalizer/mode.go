@@ -0,0 +1,183 @@
+package normalizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/pytypes"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/bblfsh/sdk.v1/uast/ann"
+	"gopkg.in/bblfsh/sdk.v1/uast/transformer"
+	"gopkg.in/bblfsh/sdk.v1/uast/transformer/annotatter"
+)
+
+// Version is the version of the conversion of the native AST into the UAST,
+// increased when the output of the transformers or the AnnotationRules
+// change. It's part of the keys of the cached UASTs.
+const Version = "1"
+
+// ModeEnv is the environment variable read by the driver to set its Mode, as
+// a comma separated list of mode names.
+const ModeEnv = "PYTHON_DRIVER_MODE"
+
+// Mode is a set of optional transformations applied to the UAST.
+type Mode int
+
+const (
+	// ModeDefault applies only the default Transformers.
+	ModeDefault Mode = 0
+	// ModeDropWhitespace removes the whitespace-only noop lines.
+	ModeDropWhitespace Mode = 1 << iota
+	// ModeValidate validates the native AST against the pyast.Schema,
+	// leniently, before converting it.
+	ModeValidate
+	// ModeValidateStrict validates the native AST strictly.
+	ModeValidateStrict
+	// ModeRecover parses the top-level statements of a file with syntax
+	// errors separately, replacing the ones that fail by Error nodes.
+	ModeRecover
+	// ModeDual parses the file with both versions of Python, returning a
+	// Versions node with the UAST of each one.
+	ModeDual
+	// ModeConcrete attaches every token and trivia of the source to the UAST
+	// with the ConcreteSyntax, so it can be regenerated byte for byte.
+	ModeConcrete
+	// ModeDesugar rewrites some constructs in terms of simpler ones with the
+	// Desugarer.
+	ModeDesugar
+	// ModeCanonical renames the local variables to a canonical form and hashes
+	// the functions and classes with the Canonicalizer.
+	ModeCanonical
+	// ModeCanonicalLiterals is ModeCanonical abstracting the literals too.
+	ModeCanonicalLiterals
+)
+
+// Internal type and properties of the nodes replacing the code with syntax
+// errors in ModeRecover. The SDK has no role for errors, so they have the
+// Incomplete role.
+const (
+	// Error is a top-level statement that couldn't be parsed.
+	Error = "Error"
+	// ErrorMessageKey is the message of the syntax errors of an Error.
+	ErrorMessageKey = "msg"
+)
+
+// Internal type and properties of the root node in ModeDual, whose children
+// are the Module nodes of each version of Python, with the PY2AST and PY3AST
+// internal roles.
+const (
+	// Versions is the root node in ModeDual.
+	Versions = "Versions"
+	// ConfidenceKey prefixes the confidence of the detector on the code being
+	// of each Python version, from 0 to 1, as "confidence.2".
+	ConfidenceKey = "confidence"
+)
+
+var modeNames = map[string]Mode{
+	"drop-whitespace":    ModeDropWhitespace,
+	"validate":           ModeValidate,
+	"validate-strict":    ModeValidateStrict,
+	"recover":            ModeRecover,
+	"dual":               ModeDual,
+	"concrete":           ModeConcrete,
+	"desugar":            ModeDesugar,
+	"canonical":          ModeCanonical,
+	"canonical-literals": ModeCanonicalLiterals,
+}
+
+// ParseMode parses a comma separated list of mode names.
+func ParseMode(s string) (Mode, error) {
+	m := ModeDefault
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		v, ok := modeNames[name]
+		if !ok {
+			return ModeDefault, fmt.Errorf("unknown mode: %q", name)
+		}
+
+		m |= v
+	}
+
+	return m, nil
+}
+
+// String returns the comma separated list of the mode names.
+func (m Mode) String() string {
+	var names []string
+	for name, v := range modeNames {
+		if m&v != 0 {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// ToNodeFor returns the `uast.ObjectToNode` converting the native ASTs with
+// the given Mode: ToNode, validating the ASTs with a Validator before its
+// OnToNode if requested.
+func ToNodeFor(m Mode) *uast.ObjectToNode {
+	var v *Validator
+	switch {
+	case m&ModeValidateStrict != 0:
+		v = NewValidator(Strict, "")
+	case m&ModeValidate != 0:
+		v = NewValidator(Lenient, "")
+	default:
+		return ToNode
+	}
+
+	toNode := *ToNode
+	toNode.OnToNode = func(ast interface{}) (interface{}, error) {
+		ast, err := v.OnToNode(ast)
+		if err != nil {
+			return nil, err
+		}
+
+		return ToNode.OnToNode(ast)
+	}
+
+	return &toNode
+}
+
+// TransformersFor returns the list of `transformer.Tranformer` to apply to a
+// UAST with the given Mode.
+func TransformersFor(m Mode) []transformer.Tranformer {
+	return TransformersWithRules(m, AnnotationRules)
+}
+
+// TransformersWithRules is like TransformersFor, annotating the UAST with the
+// given rules instead of the AnnotationRules (see Overlay).
+func TransformersWithRules(m Mode, rules *ann.Rule) []transformer.Tranformer {
+	t := []transformer.Tranformer{NewCommentBinder(), NewPragmaClassifier(),
+		NewTypeCommentParser(), NewForwardRefParser(), pytypes.NewAnnotator()}
+	if m&ModeDropWhitespace != 0 {
+		t = append(t, NewWhitespaceRemover())
+	}
+
+	if m&ModeDesugar != 0 {
+		t = append(t, NewDesugarer())
+	}
+
+	t = append(t,
+		annotatter.NewAnnotatter(rules),
+		NewPositioner(),
+	)
+
+	if m&ModeConcrete != 0 {
+		t = append(t, NewConcreteSyntax())
+	}
+
+	if m&(ModeCanonical|ModeCanonicalLiterals) != 0 {
+		t = append(t, NewCanonicalizer(m&ModeCanonicalLiterals != 0))
+	}
+
+	return t
+}
@@ -0,0 +1,210 @@
+package normalizer
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// LineEndingKey is the property set on the Module node with the line ending
+// style used by the file (one of the `LineEnding*` values).
+const LineEndingKey = "lineEnding"
+
+// Line ending styles as stored in the LineEndingKey property.
+const (
+	LineEndingNone  = "none"
+	LineEndingLF    = "LF"
+	LineEndingCRLF  = "CRLF"
+	LineEndingCR    = "CR"
+	LineEndingMixed = "mixed"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Positioner is a `transformer.Tranformer` that fills the Offset of every node
+// from its Line and Col. Unlike the SDK positioner it splits lines like the
+// Python tokenizer does: "\r\n" and lone "\r" are line breaks too, a UTF-8 BOM
+// at the start of the file is skipped by the columns of the first line and a
+// form feed is just another character of the line (str.splitlines() would
+// break the line there).
+type Positioner struct{}
+
+// NewPositioner returns a new Positioner.
+func NewPositioner() *Positioner {
+	return &Positioner{}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *Positioner) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	idx := newLineIndex([]byte(code))
+	if n.Properties != nil {
+		n.Properties[LineEndingKey] = idx.lineEnding
+	}
+
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for {
+		p := iter.Next()
+		if p.IsEmpty() {
+			break
+		}
+
+		n := p.Node()
+		if err := idx.fill(n.StartPosition); err != nil {
+			return err
+		}
+
+		if err := idx.fill(n.EndPosition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lineIndex maps one-based lines and columns to zero-based byte offsets.
+type lineIndex struct {
+	// lines holds the offset where every line starts.
+	lines []int
+	// ends holds the offset of the line terminator of every line (or the
+	// size of the file for the last one).
+	ends       []int
+	bom        int
+	size       int
+	lineEnding string
+}
+
+func newLineIndex(data []byte) *lineIndex {
+	idx := &lineIndex{size: len(data)}
+	if bytes.HasPrefix(data, utf8BOM) {
+		idx.bom = len(utf8BOM)
+	}
+
+	var lf, crlf, cr int
+	idx.lines = append(idx.lines, 0)
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case '\n':
+			lf++
+		case '\r':
+			if i+1 < len(data) && data[i+1] == '\n' {
+				crlf++
+				idx.ends = append(idx.ends, i)
+				i++
+				idx.lines = append(idx.lines, i+1)
+				continue
+			}
+			cr++
+		default:
+			continue
+		}
+
+		idx.ends = append(idx.ends, i)
+		idx.lines = append(idx.lines, i+1)
+	}
+
+	idx.ends = append(idx.ends, len(data))
+	idx.lineEnding = lineEndingStyle(lf, crlf, cr)
+	return idx
+}
+
+func lineEndingStyle(lf, crlf, cr int) string {
+	switch {
+	case lf == 0 && crlf == 0 && cr == 0:
+		return LineEndingNone
+	case crlf == 0 && cr == 0:
+		return LineEndingLF
+	case lf == 0 && cr == 0:
+		return LineEndingCRLF
+	case lf == 0 && crlf == 0:
+		return LineEndingCR
+	default:
+		return LineEndingMixed
+	}
+}
+
+func (idx *lineIndex) fill(pos *uast.Position) error {
+	if pos == nil {
+		return nil
+	}
+
+	offset, err := idx.Offset(int(pos.Line), int(pos.Col))
+	if err != nil {
+		return err
+	}
+
+	pos.Offset = uint32(offset)
+	return nil
+}
+
+// Offset returns a zero-based byte offset given a one-based line and column.
+// Columns may point past the last character of the line (to its terminator)
+// since that's where Python puts the end of some nodes.
+func (idx *lineIndex) Offset(line, col int) (int, error) {
+	if line < 1 || line > len(idx.lines) {
+		return -1, fmt.Errorf("line out of bounds: %d [%d, %d]", line, 1, len(idx.lines))
+	}
+
+	start := idx.lines[line-1]
+	if line == 1 {
+		start += idx.bom
+	}
+
+	// the line terminator ("\n", "\r" or "\r\n") counts as a single column
+	maxCol := idx.ends[line-1] - start + 1
+	if col < 1 || col > maxCol {
+		return -1, fmt.Errorf("column out of bounds: %d [%d, %d]", col, 1, maxCol)
+	}
+
+	offset := start + col - 1
+	if offset > idx.size {
+		offset = idx.size
+	}
+
+	return offset, nil
+}
+
+// Line returns the content of the given one-based line of data, without its
+// terminator, or an empty string if the line is out of bounds.
+func (idx *lineIndex) Line(data string, line int) string {
+	if line < 1 || line > len(idx.lines) {
+		return ""
+	}
+
+	start := idx.lines[line-1]
+	if line == 1 {
+		start += idx.bom
+	}
+
+	end := idx.ends[line-1]
+	if start > end {
+		return ""
+	}
+
+	return data[start:end]
+}
+
+// LineCol returns a one-based line and column given a zero-based byte offset.
+func (idx *lineIndex) LineCol(offset int) (int, int, error) {
+	if offset < 0 || offset > idx.size {
+		return 0, 0, fmt.Errorf("offset out of bounds: %d [%d, %d]", offset, 0, idx.size)
+	}
+
+	line := sort.Search(len(idx.lines), func(i int) bool {
+		return idx.lines[i] > offset
+	})
+
+	start := idx.lines[line-1]
+	if line == 1 {
+		start += idx.bom
+	}
+
+	col := offset - start + 1
+	if col < 1 {
+		col = 1
+	}
+
+	return line, col, nil
+}
@@ -0,0 +1,73 @@
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func TestLineIndexOffset(t *testing.T) {
+	require := require.New(t)
+
+	cases := []struct {
+		code       string
+		lineEnding string
+		line, col  int
+		offset     int
+	}{
+		{"a = 1\nb = 2\n", LineEndingLF, 2, 1, 6},
+		{"a = 1\r\nb = 2\r\n", LineEndingCRLF, 2, 5, 11},
+		{"a = 1\rb = 2\r", LineEndingCR, 2, 1, 6},
+		{"a = 1\r\nb = 2\nc = 3\r", LineEndingMixed, 3, 1, 13},
+		{"\xEF\xBB\xBFa = 1\nb = 2", LineEndingLF, 1, 5, 7},
+		{"\xEF\xBB\xBFa = 1\nb = 2", LineEndingLF, 2, 1, 9},
+		{"if a:\n\f    b = 2\n", LineEndingLF, 2, 6, 11},
+		{"pass", LineEndingNone, 1, 1, 0},
+	}
+
+	for _, c := range cases {
+		idx := newLineIndex([]byte(c.code))
+		require.Equal(c.lineEnding, idx.lineEnding, "%q", c.code)
+
+		offset, err := idx.Offset(c.line, c.col)
+		require.NoError(err, "%q", c.code)
+		require.Equal(c.offset, offset, "%q", c.code)
+
+		line, col, err := idx.LineCol(offset)
+		require.NoError(err, "%q", c.code)
+		require.Equal(c.line, line, "%q", c.code)
+		require.Equal(c.col, col, "%q", c.code)
+	}
+}
+
+func TestLineIndexOutOfBounds(t *testing.T) {
+	require := require.New(t)
+
+	idx := newLineIndex([]byte("a = 1\r\nb = 2\r\n"))
+
+	_, err := idx.Offset(4, 1)
+	require.Error(err)
+
+	// "\r\n" is a single column
+	_, err = idx.Offset(1, 6)
+	require.NoError(err)
+	_, err = idx.Offset(1, 7)
+	require.Error(err)
+}
+
+func TestPositionerLineEnding(t *testing.T) {
+	require := require.New(t)
+
+	n := uast.NewNode()
+	n.InternalType = "Module"
+	child := uast.NewNode()
+	child.StartPosition = &uast.Position{Line: 2, Col: 3}
+	n.Children = append(n.Children, child)
+
+	err := NewPositioner().Do("a = 1\r\nb = 2\r\n", protocol.UTF8, n)
+	require.NoError(err)
+	require.Equal(LineEndingCRLF, n.Properties[LineEndingKey])
+	require.Equal(uint32(9), child.StartPosition.Offset)
+}
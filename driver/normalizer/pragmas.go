@@ -0,0 +1,166 @@
+package normalizer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/pep263"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Internal types given by the PragmaClassifier to the comments it recognizes.
+const (
+	// Shebang is the "#!" interpreter line at the start of the file.
+	Shebang = "Shebang"
+	// EncodingDeclaration is the PEP 263 coding cookie.
+	EncodingDeclaration = "EncodingDeclaration"
+	// Pragma is a comment directed to a tool (linters and type checkers).
+	Pragma = "Pragma"
+)
+
+// Properties holding the payload of the classified comments. The encoding of
+// an EncodingDeclaration is stored in pep263.EncodingKey.
+const (
+	// InterpreterKey is the interpreter command line of a Shebang.
+	InterpreterKey = "interpreter"
+	// ToolKey is the tool a Pragma is directed to: "flake8", "pylint" or
+	// "typing".
+	ToolKey = "tool"
+	// ActionKey is the action of a pylint Pragma ("disable", "enable"...).
+	ActionKey = "action"
+	// CodesKey is the comma separated list of the codes of a Pragma, empty
+	// for all of them.
+	CodesKey = "codes"
+	// ScopeKey is what a Pragma applies to: PragmaModule, PragmaBlock or
+	// PragmaLine.
+	ScopeKey = "scope"
+)
+
+// Scopes of a Pragma.
+const (
+	// PragmaModule are the pragmas in their own line at the module level.
+	PragmaModule = "module"
+	// PragmaBlock are the pragmas in their own indented line, they apply to
+	// the rest of the block.
+	PragmaBlock = "block"
+	// PragmaLine are the pragmas at the end of a line of code, they silence
+	// the statement they're bound to.
+	PragmaLine = "line"
+)
+
+type pragma struct {
+	tool  string
+	regex *regexp.Regexp
+}
+
+// pragmas are the regular expressions of the comments recognized as pragmas.
+// The codes are always the last group and the pylint action the first one.
+var pragmas = []pragma{
+	{"flake8", regexp.MustCompile(`(?i)#\s*flake8[:=]\s*noqa()`)},
+	{"flake8", regexp.MustCompile(`(?i)#\s*noqa(?::\s?([A-Z]+[0-9]+(?:[,\s]+[A-Z]+[0-9]+)*))?`)},
+	{"pylint", regexp.MustCompile(`#\s*pylint\s*:\s*([\w-]+)\s*=?\s*([\w-]+(?:\s*,\s*[\w-]+)*)?`)},
+	{"typing", regexp.MustCompile(`#\s*type:\s*ignore(?:\[([^\]]*)\])?`)},
+}
+
+var codesSeparator = regexp.MustCompile(`[,\s]+`)
+
+// PragmaClassifier is a `transformer.Tranformer` that changes the internal type
+// of the comments with a special meaning to Shebang, EncodingDeclaration or
+// Pragma, adding their parsed payload as properties. Line-level pragmas are
+// left under the statement the CommentBinder bound them to.
+type PragmaClassifier struct{}
+
+// NewPragmaClassifier returns a new PragmaClassifier.
+func NewPragmaClassifier() *PragmaClassifier {
+	return &PragmaClassifier{}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *PragmaClassifier) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	b := &binder{code: code, idx: newLineIndex([]byte(code))}
+	encoding := pep263.Detect([]byte(code))
+
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		n := p.Node()
+		switch n.InternalType {
+		case "NoopLine":
+			if !b.isComment(n) {
+				continue
+			}
+
+			b.classifyLine(n, encoding)
+		case "SameLineNoops":
+			// the token is the list of comments at the end of the line
+			text := strings.TrimSuffix(strings.TrimPrefix(n.Token, "["), "]")
+			classifyPragma(n, text, PragmaLine)
+		}
+	}
+
+	return nil
+}
+
+func (b *binder) classifyLine(n *uast.Node, encoding string) {
+	l := line(n)
+	text := b.idx.Line(b.code, l)
+	switch {
+	case l == 1 && strings.HasPrefix(text, "#!"):
+		n.InternalType = Shebang
+		n.Properties[InterpreterKey] = strings.TrimSpace(text[2:])
+	case l <= 2 && encoding != "" && pep263.Cookie(text) == encoding:
+		n.InternalType = EncodingDeclaration
+		n.Properties[pep263.EncodingKey] = encoding
+	default:
+		scope := PragmaModule
+		if strings.TrimLeft(text, " \t\f") != text {
+			scope = PragmaBlock
+		}
+
+		classifyPragma(n, text, scope)
+	}
+}
+
+// classifyPragma turns n into a Pragma if text holds one, the first one found
+// if there are many.
+func classifyPragma(n *uast.Node, text, scope string) {
+	var (
+		found *pragma
+		match []int
+	)
+
+	for i, p := range pragmas {
+		m := p.regex.FindStringSubmatchIndex(text)
+		if m != nil && (match == nil || m[0] < match[0]) {
+			found, match = &pragmas[i], m
+		}
+	}
+
+	if found == nil {
+		return
+	}
+
+	group := func(i int) string {
+		if match[2*i] < 0 {
+			return ""
+		}
+
+		return text[match[2*i]:match[2*i+1]]
+	}
+
+	var codes []string
+	for _, c := range codesSeparator.Split(group(len(match)/2-1), -1) {
+		if c != "" {
+			codes = append(codes, c)
+		}
+	}
+
+	n.InternalType = Pragma
+	n.Properties[ToolKey] = found.tool
+	n.Properties[CodesKey] = strings.Join(codes, ",")
+	n.Properties[ScopeKey] = scope
+	if found.tool == "pylint" {
+		n.Properties[ActionKey] = group(1)
+	}
+}
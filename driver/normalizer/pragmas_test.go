@@ -0,0 +1,89 @@
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/pep263"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func TestPragmaClassifierHeader(t *testing.T) {
+	require := require.New(t)
+
+	code := "#!/usr/bin/env python3\n# -*- coding: utf-8 -*-\n# type: ignore\n" +
+		"# pylint: disable=C0111, W0611\n# flake8: noqa\n# just a comment\n\nx = 1\n"
+
+	var lines []*uast.Node
+	for i := uint32(1); i <= 6; i++ {
+		lines = append(lines, noopLine(i, ""))
+	}
+
+	module := &uast.Node{InternalType: "Module", Children: []*uast.Node{{
+		InternalType: "PreviousNoops",
+		Children:     lines,
+	}}}
+
+	err := NewPragmaClassifier().Do(code, protocol.UTF8, module)
+	require.NoError(err)
+
+	require.Equal(Shebang, lines[0].InternalType)
+	require.Equal("/usr/bin/env python3", lines[0].Properties[InterpreterKey])
+
+	require.Equal(EncodingDeclaration, lines[1].InternalType)
+	require.Equal("utf-8", lines[1].Properties[pep263.EncodingKey])
+
+	expected := []map[string]string{{
+		ToolKey: "typing", CodesKey: "", ScopeKey: PragmaModule,
+	}, {
+		ToolKey: "pylint", ActionKey: "disable", CodesKey: "C0111,W0611", ScopeKey: PragmaModule,
+	}, {
+		ToolKey: "flake8", CodesKey: "", ScopeKey: PragmaModule,
+	}}
+
+	for i, props := range expected {
+		n := lines[i+2]
+		require.Equal(Pragma, n.InternalType)
+		for k, v := range props {
+			require.Equal(v, n.Properties[k], "%d: %s", i, k)
+		}
+	}
+
+	require.Equal("NoopLine", lines[5].InternalType)
+}
+
+func TestPragmaClassifierLine(t *testing.T) {
+	require := require.New(t)
+
+	code := "def f():\n    # pylint: enable=R0201\n    x = 1  # noqa: E501,W291\n"
+	block := noopLine(2, "")
+	pragma := &uast.Node{
+		InternalType:  "SameLineNoops",
+		Properties:    map[string]string{uast.InternalRoleKey: CommentsTrailing},
+		Token:         "[# noqa: E501,W291]",
+		StartPosition: &uast.Position{Line: 3, Col: 12},
+	}
+
+	module := &uast.Node{InternalType: "Module", Children: []*uast.Node{{
+		InternalType: "FunctionDef",
+		Children:     []*uast.Node{block, {InternalType: "Assign", Children: []*uast.Node{pragma}}},
+	}}}
+
+	err := NewPragmaClassifier().Do(code, protocol.UTF8, module)
+	require.NoError(err)
+
+	require.Equal(Pragma, block.InternalType)
+	require.Equal(PragmaBlock, block.Properties[ScopeKey])
+	require.Equal("enable", block.Properties[ActionKey])
+	require.Equal("R0201", block.Properties[CodesKey])
+
+	require.Equal(Pragma, pragma.InternalType)
+	require.Equal(PragmaLine, pragma.Properties[ScopeKey])
+	require.Equal("flake8", pragma.Properties[ToolKey])
+	require.Equal("E501,W291", pragma.Properties[CodesKey])
+
+	_, trailing := Comments(module.Children[0].Children[1])
+	require.Equal([]*uast.Node{pragma}, trailing)
+}
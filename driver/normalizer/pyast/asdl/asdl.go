@@ -0,0 +1,280 @@
+// Package asdl parses the Zephyr Abstract Syntax Description Language files
+// that define the Python AST (Parser/Python.asdl in CPython).
+package asdl
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrSyntax is returned when an ASDL file can't be parsed.
+var ErrSyntax = errors.NewKind("asdl: line %d: %s")
+
+// Cardinality is the number of values of a field.
+type Cardinality int
+
+const (
+	// One is a required field.
+	One Cardinality = iota
+	// Optional is a field that may be missing, as in `expr? value`.
+	Optional
+	// Sequence is a list of values, as in `stmt* body`.
+	Sequence
+)
+
+// String returns the ASDL notation of the cardinality: "", "?" or "*".
+func (c Cardinality) String() string {
+	switch c {
+	case Optional:
+		return "?"
+	case Sequence:
+		return "*"
+	}
+
+	return ""
+}
+
+// Field is a field of a constructor or a product type.
+type Field struct {
+	Name        string
+	Type        string
+	Cardinality Cardinality
+}
+
+// String returns the ASDL declaration of the field.
+func (f *Field) String() string {
+	return fmt.Sprintf("%s%s %s", f.Type, f.Cardinality, f.Name)
+}
+
+// Constructor is a constructor of a sum type.
+type Constructor struct {
+	Name   string
+	Fields []*Field
+}
+
+// Type is a sum type (as `expr = BinOp(...) | Name(...)`) or a product type
+// (as `alias = (identifier name, identifier? asname)`).
+type Type struct {
+	Name string
+	// Constructors are the constructors of a sum type, nil for products.
+	Constructors []*Constructor
+	// Fields are the fields of a product type.
+	Fields []*Field
+	// Attributes are the fields shared by all the constructors, as lineno.
+	Attributes []*Field
+}
+
+// Product returns true if the type is a product type.
+func (t *Type) Product() bool {
+	return t.Constructors == nil
+}
+
+// Module is a parsed ASDL file.
+type Module struct {
+	Name  string
+	Types []*Type
+}
+
+// Type returns the type with the given name or nil.
+func (m *Module) Type(name string) *Type {
+	for _, t := range m.Types {
+		if t.Name == name {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// Parse reads and parses an ASDL module.
+func Parse(r io.Reader) (*Module, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{lex: &lexer{src: string(data), line: 1}}
+	p.next()
+	return p.module()
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) next() {
+	p.tok = p.lex.next()
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return ErrSyntax.New(p.tok.line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) expect(kind tokenKind, text string) (string, error) {
+	t := p.tok
+	if t.kind != kind || (text != "" && t.text != text) {
+		want := text
+		if want == "" {
+			want = kind.String()
+		}
+
+		return "", p.errorf("expected %s, found %s", want, t)
+	}
+
+	p.next()
+	return t.text, nil
+}
+
+func (p *parser) is(kind tokenKind, text string) bool {
+	return p.tok.kind == kind && p.tok.text == text
+}
+
+// module = "module" id ["version" string] "{" {definition} "}"
+func (p *parser) module() (*Module, error) {
+	if _, err := p.expect(identifier, "module"); err != nil {
+		return nil, err
+	}
+
+	name, err := p.expect(identifier, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if p.is(identifier, "version") {
+		p.next()
+		if _, err := p.expect(str, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.expect(punct, "{"); err != nil {
+		return nil, err
+	}
+
+	m := &Module{Name: name}
+	for !p.is(punct, "}") {
+		t, err := p.definition()
+		if err != nil {
+			return nil, err
+		}
+
+		if m.Type(t.Name) != nil {
+			return nil, p.errorf("type %s redefined", t.Name)
+		}
+
+		m.Types = append(m.Types, t)
+	}
+
+	p.next()
+	if p.tok.kind != eof {
+		return nil, p.errorf("unexpected %s after the module", p.tok)
+	}
+
+	return m, nil
+}
+
+// definition = id "=" (fields | constructor {"|" constructor}) [attributes]
+func (p *parser) definition() (*Type, error) {
+	name, err := p.expect(identifier, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(punct, "="); err != nil {
+		return nil, err
+	}
+
+	t := &Type{Name: name}
+	if p.is(punct, "(") {
+		if t.Fields, err = p.fields(); err != nil {
+			return nil, err
+		}
+	} else {
+		for {
+			c, err := p.constructor()
+			if err != nil {
+				return nil, err
+			}
+
+			t.Constructors = append(t.Constructors, c)
+			if !p.is(punct, "|") {
+				break
+			}
+
+			p.next()
+		}
+	}
+
+	if p.is(identifier, "attributes") {
+		p.next()
+		if t.Attributes, err = p.fields(); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// constructor = id [fields]
+func (p *parser) constructor() (*Constructor, error) {
+	name, err := p.expect(identifier, "")
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Constructor{Name: name}
+	if p.is(punct, "(") {
+		if c.Fields, err = p.fields(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// fields = "(" field {"," field} ")"
+// field = id ["?" | "*"] id
+func (p *parser) fields() ([]*Field, error) {
+	if _, err := p.expect(punct, "("); err != nil {
+		return nil, err
+	}
+
+	var fields []*Field
+	for {
+		typ, err := p.expect(identifier, "")
+		if err != nil {
+			return nil, err
+		}
+
+		f := &Field{Type: typ}
+		switch {
+		case p.is(punct, "?"):
+			f.Cardinality = Optional
+			p.next()
+		case p.is(punct, "*"):
+			f.Cardinality = Sequence
+			p.next()
+		}
+
+		if f.Name, err = p.expect(identifier, ""); err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, f)
+		if !p.is(punct, ",") {
+			break
+		}
+
+		p.next()
+	}
+
+	if _, err := p.expect(punct, ")"); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,82 @@
+package asdl
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	m, err := Parse(strings.NewReader(`
+-- a comment
+module Test version "1.0"
+{
+	expr = Name(identifier id, expr_context ctx) -- trailing comment
+	     | Call(expr func, expr* args, keyword* keywords, expr? starargs)
+	     | Ellipsis
+	     attributes (int lineno, int col_offset)
+
+	keyword = (identifier arg, expr value)
+}
+`))
+	require.NoError(err)
+	require.Equal("Test", m.Name)
+	require.Len(m.Types, 2)
+
+	expr := m.Type("expr")
+	require.False(expr.Product())
+	require.Len(expr.Constructors, 3)
+	require.Equal("Call", expr.Constructors[1].Name)
+	require.Equal(&Field{Name: "args", Type: "expr", Cardinality: Sequence}, expr.Constructors[1].Fields[1])
+	require.Equal("expr? starargs", expr.Constructors[1].Fields[3].String())
+	require.Empty(expr.Constructors[2].Fields)
+	require.Len(expr.Attributes, 2)
+
+	keyword := m.Type("keyword")
+	require.True(keyword.Product())
+	require.Len(keyword.Fields, 2)
+	require.Nil(m.Type("stmt"))
+}
+
+func TestParseErrors(t *testing.T) {
+	require := require.New(t)
+
+	cases := []string{
+		"",
+		"module {}",
+		"module M { expr = }",
+		"module M { expr = Name(identifier) }",
+		"module M { expr = Name(identifier id }",
+		"module M { expr = Name\n\texpr = Call }",
+		"module M { expr = Name } extra",
+		"module M { expr = Name$ }",
+	}
+
+	for _, src := range cases {
+		_, err := Parse(strings.NewReader(src))
+		require.True(ErrSyntax.Is(err), "%q: %v", src, err)
+	}
+
+	_, err := Parse(strings.NewReader("module M {\n\n expr = Name(identifier) }"))
+	require.EqualError(err, `asdl: line 3: expected identifier, found ")"`)
+}
+
+func TestParsePythonGrammars(t *testing.T) {
+	require := require.New(t)
+
+	for _, v := range []string{"2.7", "3.6", "3.8", "3.11"} {
+		f, err := os.Open("../grammar/Python-" + v + ".asdl")
+		require.NoError(err)
+
+		m, err := Parse(f)
+		f.Close()
+		require.NoError(err, v)
+		require.Equal("Python", m.Name)
+		require.NotNil(m.Type("stmt"), v)
+		require.NotNil(m.Type("expr"), v)
+	}
+}
@@ -0,0 +1,111 @@
+package asdl
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	eof tokenKind = iota
+	identifier
+	str
+	punct
+	invalid
+)
+
+var tokenKindNames = map[tokenKind]string{
+	eof:        "end of file",
+	identifier: "identifier",
+	str:        "string",
+	punct:      "punctuation",
+	invalid:    "invalid character",
+}
+
+func (k tokenKind) String() string {
+	return tokenKindNames[k]
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+func (t token) String() string {
+	if t.kind == eof {
+		return t.kind.String()
+	}
+
+	return fmt.Sprintf("%q", t.text)
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (l *lexer) next() token {
+	l.skip()
+	if l.pos >= len(l.src) {
+		return token{kind: eof, line: l.line}
+	}
+
+	start, c := l.pos, l.src[l.pos]
+	switch {
+	case isIdentStart(c):
+		for l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+			l.pos++
+		}
+
+		return token{kind: identifier, text: l.src[start:l.pos], line: l.line}
+	case c == '"':
+		end := strings.IndexAny(l.src[start+1:], "\"\n")
+		if end < 0 || l.src[start+1+end] != '"' {
+			l.pos = len(l.src)
+			return token{kind: invalid, text: l.src[start:], line: l.line}
+		}
+
+		l.pos = start + end + 2
+		return token{kind: str, text: l.src[start+1 : l.pos-1], line: l.line}
+	case strings.IndexByte("=|(),?*{}", c) >= 0:
+		l.pos++
+		return token{kind: punct, text: string(c), line: l.line}
+	}
+
+	l.pos++
+	return token{kind: invalid, text: string(c), line: l.line}
+}
+
+// skip skips the whitespace and the comments, from "--" to the end of the
+// line.
+func (l *lexer) skip() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case strings.HasPrefix(l.src[l.pos:], "--"):
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				l.pos = len(l.src)
+			} else {
+				l.pos += end
+			}
+		default:
+			return
+		}
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || '0' <= c && c <= '9'
+}
@@ -0,0 +1,320 @@
+// Package builder constructs Python UAST nodes from code, to synthesize code
+// as stubs or migrations. The constructors build the native AST of the node,
+// as the native driver answers it for Python 3, and Build converts it as the
+// driver does, so the UAST has the same types, properties and roles as the
+// one of the parsed code, without positions:
+//
+//	n, err := builder.Build(builder.Module(
+//		builder.Import(builder.Alias("os", "")),
+//		builder.FunctionDef("cwd", builder.Args(),
+//			builder.Return(builder.Call(builder.Attribute(builder.Name("os"), "getcwd"))),
+//		),
+//	))
+package builder
+
+import (
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Node is a node of the native AST, as decoded from the JSON of the native
+// driver. Its fields can be set directly for the ones without a constructor
+// argument, as the decorator_list of a FunctionDef.
+type Node map[string]interface{}
+
+// Type returns the internal type of the node.
+func (n Node) Type() string {
+	t, _ := n["ast_type"].(string)
+	return t
+}
+
+// Build returns the UAST of a node, annotated with the normalizer
+// AnnotationRules. The nodes other than a Module are built inside one, as a
+// statement of its body, or as the value of an Expr for the expressions, and
+// returned from it.
+func Build(n Node) (*uast.Node, error) {
+	module := n
+	if n.Type() != "Module" {
+		stmt := n
+		if isExpr(n) {
+			stmt = Expr(n)
+		}
+
+		module = Module(stmt)
+	}
+
+	root, err := normalizer.ToNode.ToNode(map[string]interface{}{"PY3AST": map[string]interface{}(module)})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalizer.AnnotationRules.Apply(root); err != nil {
+		return nil, err
+	}
+
+	if n.Type() == "Module" {
+		return root, nil
+	}
+
+	built := root.Children[0]
+	if isExpr(n) {
+		built = built.Children[0]
+	}
+
+	return built, nil
+}
+
+// isExpr returns true for the expressions, including the ones added by the
+// native driver, as NoneLiteral.
+func isExpr(n Node) bool {
+	s, ok := pyast.Schema[n.Type()]
+	if !ok {
+		return true
+	}
+
+	for _, t := range s.Types {
+		if t == "expr" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func node(typ string, fields Node) Node {
+	fields["ast_type"] = typ
+	return fields
+}
+
+func list(nodes []Node) []interface{} {
+	l := make([]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		l = append(l, map[string]interface{}(n))
+	}
+
+	return l
+}
+
+// optional returns the field of an optional node, nil if n is nil.
+func optional(n Node) interface{} {
+	if n == nil {
+		return nil
+	}
+
+	return map[string]interface{}(n)
+}
+
+// Module returns a module with the given statements.
+func Module(body ...Node) Node {
+	return node("Module", Node{"body": list(body)})
+}
+
+// Expr returns the statement of an expression.
+func Expr(value Node) Node {
+	return node("Expr", Node{"value": map[string]interface{}(value)})
+}
+
+// Pass returns a pass statement.
+func Pass() Node {
+	return node("Pass", Node{})
+}
+
+// Return returns a return statement, without value if value is nil.
+func Return(value Node) Node {
+	return node("Return", Node{"value": optional(value)})
+}
+
+// Assign returns the assignment of value to target. The names, attributes,
+// subscripts, tuples and lists in target get the Store context.
+func Assign(target, value Node) Node {
+	return node("Assign", Node{
+		"targets": list([]Node{store(target)}),
+		"value":   map[string]interface{}(value),
+	})
+}
+
+// store sets the Store context of a target and of its elements.
+func store(n Node) Node {
+	if _, ok := n["ctx"]; ok {
+		n["ctx"] = "Store"
+	}
+
+	if elts, ok := n["elts"].([]interface{}); ok {
+		for _, e := range elts {
+			if m, ok := e.(map[string]interface{}); ok {
+				store(Node(m))
+			}
+		}
+	}
+
+	return n
+}
+
+// Import returns an import statement of the given aliases.
+func Import(names ...Node) Node {
+	return node("Import", Node{"names": list(names)})
+}
+
+// ImportFrom returns a from import statement of the given aliases from an
+// absolute module.
+func ImportFrom(module string, names ...Node) Node {
+	return node("ImportFrom", Node{
+		"module": module,
+		"names":  list(names),
+		"level":  float64(0),
+	})
+}
+
+// Alias returns the name of an import, renamed to asname if not empty.
+func Alias(name, asname string) Node {
+	var as interface{}
+	if asname != "" {
+		as = asname
+	}
+
+	return node("alias", Node{"name": name, "asname": as})
+}
+
+// FunctionDef returns the definition of a function.
+func FunctionDef(name string, args Node, body ...Node) Node {
+	return node("FunctionDef", Node{
+		"name":           name,
+		"args":           map[string]interface{}(args),
+		"body":           list(body),
+		"decorator_list": list(nil),
+		"returns":        nil,
+	})
+}
+
+// Args returns the arguments of a function with the given positional
+// parameters.
+func Args(names ...string) Node {
+	var args []Node
+	for _, name := range names {
+		args = append(args, node("arg", Node{"arg": name, "annotation": nil}))
+	}
+
+	return node("arguments", Node{
+		"args":        list(args),
+		"defaults":    list(nil),
+		"kw_defaults": list(nil),
+		"kwonlyargs":  list(nil),
+		"kwarg":       nil,
+		"vararg":      nil,
+	})
+}
+
+// ClassDef returns the definition of a class with the given bases.
+func ClassDef(name string, bases []Node, body ...Node) Node {
+	return node("ClassDef", Node{
+		"name":           name,
+		"bases":          list(bases),
+		"body":           list(body),
+		"decorator_list": list(nil),
+		"keywords":       list(nil),
+	})
+}
+
+// If returns an if statement, with an else clause if orelse isn't empty.
+func If(test Node, body []Node, orelse ...Node) Node {
+	return node("If", Node{
+		"test":   map[string]interface{}(test),
+		"body":   list(body),
+		"orelse": list(orelse),
+	})
+}
+
+// Name returns a variable loaded.
+func Name(id string) Node {
+	return node("Name", Node{"id": id, "ctx": "Load"})
+}
+
+// Attribute returns the attribute attr of value, loaded.
+func Attribute(value Node, attr string) Node {
+	return node("Attribute", Node{
+		"value": map[string]interface{}(value),
+		"attr":  attr,
+		"ctx":   "Load",
+	})
+}
+
+// Call returns a call of fn. The Keyword arguments go to its keywords, the
+// rest to its positional arguments.
+func Call(fn Node, args ...Node) Node {
+	var positional, keywords []Node
+	for _, a := range args {
+		if a.Type() == "keyword" {
+			keywords = append(keywords, a)
+		} else {
+			positional = append(positional, a)
+		}
+	}
+
+	return node("Call", Node{
+		"func":     map[string]interface{}(fn),
+		"args":     list(positional),
+		"keywords": list(keywords),
+	})
+}
+
+// Keyword returns a keyword argument of a Call.
+func Keyword(arg string, value Node) Node {
+	return node("keyword", Node{"arg": arg, "value": map[string]interface{}(value)})
+}
+
+// BinOp returns a binary operation, op being the type of its operator in the
+// Python AST, as "Add".
+func BinOp(left Node, op string, right Node) Node {
+	return node("BinOp", Node{
+		"left":  map[string]interface{}(left),
+		"op":    map[string]interface{}(node(op, Node{})),
+		"right": map[string]interface{}(right),
+	})
+}
+
+// Compare returns a comparison of left and right, op being the type of its
+// operator in the Python AST, as "Eq".
+func Compare(left Node, op string, right Node) Node {
+	return node("Compare", Node{
+		"left":        map[string]interface{}(left),
+		"ops":         list([]Node{node(op, Node{})}),
+		"comparators": list([]Node{right}),
+	})
+}
+
+// Str returns a string literal.
+func Str(s string) Node {
+	return node("Str", Node{"s": s})
+}
+
+// Num returns a number literal.
+func Num(n float64) Node {
+	return node("Num", Node{"n": n})
+}
+
+// Bool returns True or False.
+func Bool(b bool) Node {
+	s := "False"
+	if b {
+		s = "True"
+	}
+
+	return node("BoolLiteral", Node{"LiteralValue": s, "value": b})
+}
+
+// None returns None.
+func None() Node {
+	return node("NoneLiteral", Node{"LiteralValue": "None"})
+}
+
+// List returns a list display, loaded.
+func List(elts ...Node) Node {
+	return node("List", Node{"elts": list(elts), "ctx": "Load"})
+}
+
+// Tuple returns a tuple, loaded.
+func Tuple(elts ...Node) Node {
+	return node("Tuple", Node{"elts": list(elts), "ctx": "Load"})
+}
@@ -0,0 +1,112 @@
+package builder
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// parsed returns the annotated UAST of a fixture, without positions.
+func parsed(t *testing.T, name string) *uast.Node {
+	require := require.New(t)
+
+	native, err := ioutil.ReadFile(filepath.Join("..", "..", "..", "..", "fixtures", name+".native"))
+	require.NoError(err)
+
+	var resp struct {
+		AST map[string]interface{} `json:"ast"`
+	}
+
+	require.NoError(json.Unmarshal(native, &resp))
+	n, err := normalizer.ToNode.ToNode(resp.AST)
+	require.NoError(err)
+	require.NoError(normalizer.AnnotationRules.Apply(n))
+
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		p.Node().StartPosition, p.Node().EndPosition = nil, nil
+	}
+
+	return n
+}
+
+func TestBuildFixtures(t *testing.T) {
+	require := require.New(t)
+
+	cases := map[string]Node{
+		// import sys
+		// sys.stdout.write("Hello world!\n")
+		"issue30.py": Module(
+			Import(Alias("sys", "")),
+			Expr(Call(Attribute(Attribute(Name("sys"), "stdout"), "write"), Str("Hello world!\n"))),
+		),
+		// def testfnc1(a, b):
+		//     a = b
+		//     return 1
+		"u2_func_simple.py": Module(
+			FunctionDef("testfnc1", Args("a", "b"),
+				Assign(Name("a"), Name("b")),
+				Return(Num(1)),
+			),
+		),
+		// import a as b
+		// from c import e as f
+		"u2_import_rename.py": Module(
+			Import(Alias("a", "b")),
+			ImportFrom("c", Alias("e", "f")),
+		),
+		// class testcls1:
+		//     __slots__ = ['a', 'b']
+		"u2_class_specific_slots.py": Module(
+			ClassDef("testcls1", nil,
+				Assign(Name("__slots__"), List(Str("a"), Str("b"))),
+			),
+		),
+		// a, b = 0, None
+		"issue58.py": Module(
+			Assign(Tuple(Name("a"), Name("b")), Tuple(Num(0), None())),
+		),
+		// if 1 > 2:
+		//     pass
+		"test.py": Module(
+			If(Compare(Num(1), "Gt", Num(2)), []Node{Pass()}),
+		),
+	}
+
+	for name, m := range cases {
+		built, err := Build(m)
+		require.NoError(err, name)
+		require.Equal(parsed(t, name), built, name)
+	}
+}
+
+func TestBuildExpression(t *testing.T) {
+	require := require.New(t)
+
+	// f(a=True)
+	n, err := Build(Call(Name("f"), Keyword("a", Bool(true))))
+	require.NoError(err)
+	require.Equal("Call", n.InternalType)
+	require.Contains(n.Roles, uast.Call)
+	require.Len(n.Children, 2)
+
+	kw := n.Children[1]
+	require.Equal("keyword", kw.InternalType)
+	require.Equal("a", kw.Token)
+	require.Contains(kw.Roles, uast.Argument)
+}
+
+func TestBuildStatement(t *testing.T) {
+	require := require.New(t)
+
+	n, err := Build(Pass())
+	require.NoError(err)
+	require.Equal("Pass", n.InternalType)
+	require.Equal("pass", n.Token)
+	require.Contains(n.Roles, uast.Noop)
+}
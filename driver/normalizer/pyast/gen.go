@@ -0,0 +1,309 @@
+//go:build ignore
+// +build ignore
+
+// gen.go generates pyast_generated.go from the grammars of the Python AST in
+// grammar/Python-<version>.asdl. Run it with `go generate`.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl"
+)
+
+const output = "pyast_generated.go"
+
+type grammar struct {
+	version string
+	module  *asdl.Module
+}
+
+type node struct {
+	name     string
+	abstract bool
+	types    []string
+	fields   []*field
+	versions []int
+}
+
+type field struct {
+	asdl.Field
+	versions []int
+}
+
+func main() {
+	grammars, err := readGrammars("grammar")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	nodes := make(map[string]*node)
+	get := func(name string) *node {
+		n, ok := nodes[name]
+		if !ok {
+			n = &node{name: name}
+			nodes[name] = n
+		}
+
+		return n
+	}
+
+	// from the newest to the oldest grammar, so fields are in the order of
+	// the newest version they exist in
+	for i := len(grammars) - 1; i >= 0; i-- {
+		for _, t := range grammars[i].module.Types {
+			n := get(t.Name)
+			n.versions = append(n.versions, i)
+			if t.Product() {
+				n.addFields(i, t.Fields)
+				continue
+			}
+
+			n.abstract = true
+			for _, c := range t.Constructors {
+				n := get(c.Name)
+				n.versions = append(n.versions, i)
+				n.addType(t.Name)
+				n.addFields(i, c.Fields)
+			}
+		}
+	}
+
+	var names []string
+	for name, n := range nodes {
+		if err := n.check(grammars); err != nil {
+			log.Fatal(err)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	src, err := generate(grammars, nodes, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readGrammars(dir string) ([]*grammar, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "Python-*.asdl"))
+	if err != nil {
+		return nil, err
+	}
+
+	var grammars []*grammar
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := asdl.Parse(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+
+		v := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "Python-"), ".asdl")
+		grammars = append(grammars, &grammar{version: v, module: m})
+	}
+
+	sort.Slice(grammars, func(i, j int) bool {
+		return versionLess(grammars[i].version, grammars[j].version)
+	})
+
+	return grammars, nil
+}
+
+func versionLess(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			return x < y
+		}
+	}
+
+	return len(as) < len(bs)
+}
+
+func (n *node) addType(name string) {
+	for _, t := range n.types {
+		if t == name {
+			return
+		}
+	}
+
+	n.types = append(n.types, name)
+}
+
+func (n *node) addFields(version int, fields []*asdl.Field) {
+	for _, f := range fields {
+		found := false
+		for _, nf := range n.fields {
+			if nf.Field == *f {
+				nf.versions = append(nf.versions, version)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			n.fields = append(n.fields, &field{Field: *f, versions: []int{version}})
+		}
+	}
+}
+
+// check fails if the node or any of its fields is missing in a version
+// between the first and the last ones it exists in, as a version range
+// couldn't describe it.
+func (n *node) check(grammars []*grammar) error {
+	if !contiguous(n.versions) {
+		return fmt.Errorf("node %s doesn't exist in a contiguous range of versions", n.name)
+	}
+
+	for _, f := range n.fields {
+		if !contiguous(f.versions) {
+			return fmt.Errorf("field %s.%s doesn't exist in a contiguous range of versions", n.name, f.Name)
+		}
+	}
+
+	return nil
+}
+
+// contiguous checks a list of decreasing grammar indexes.
+func contiguous(versions []int) bool {
+	for i := 1; i < len(versions); i++ {
+		if versions[i] != versions[i-1]-1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func versionRange(grammars []*grammar, versions []int) string {
+	return fmt.Sprintf("VersionRange{Since: %q, Until: %q}",
+		grammars[versions[len(versions)-1]].version, grammars[versions[0]].version)
+}
+
+var cardinalities = map[asdl.Cardinality]string{
+	asdl.One:      "asdl.One",
+	asdl.Optional: "asdl.Optional",
+	asdl.Sequence: "asdl.Sequence",
+}
+
+func generate(grammars []*grammar, nodes map[string]*node, names []string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := func(format string, args ...interface{}) {
+		fmt.Fprintf(&buf, format, args...)
+	}
+
+	var versions []string
+	for _, g := range grammars {
+		versions = append(versions, strconv.Quote(g.version))
+	}
+
+	w("// Code generated by gen.go from grammar/*.asdl. DO NOT EDIT.\n\n")
+	w("package pyast\n\n")
+	w("import (\n\"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl\"\n")
+	w("\"gopkg.in/bblfsh/sdk.v1/uast/ann\"\n)\n\n")
+	w("// Versions are the Python versions whose grammars are included.\n")
+	w("var Versions = []string{%s}\n\n", strings.Join(versions, ", "))
+
+	w("// Python AST node types, from all the Versions.\n")
+	w("var (\n")
+	goNames := goNames(nodes)
+	sorted := append([]string(nil), names...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return goNames[sorted[i]] < goNames[sorted[j]]
+	})
+
+	for _, name := range sorted {
+		w("%s = ann.HasInternalType(%q)\n", goNames[name], name)
+	}
+
+	w(")\n\n")
+	w("// Schema is the node types of the Python AST by name, from all the\n")
+	w("// Versions.\n")
+	w("var Schema = map[string]*NodeSchema{\n")
+	for _, name := range names {
+		n := nodes[name]
+		w("%q: {\nName: %q,\n", name, name)
+		if n.abstract {
+			w("Abstract: true,\n")
+		}
+
+		if len(n.types) > 0 {
+			var types []string
+			for _, t := range n.types {
+				types = append(types, strconv.Quote(t))
+			}
+
+			w("Types: []string{%s},\n", strings.Join(types, ", "))
+		}
+
+		if len(n.fields) > 0 {
+			w("Fields: []*FieldSchema{\n")
+			for _, f := range n.fields {
+				w("{Name: %q, Type: %q, Cardinality: %s, VersionRange: %s},\n",
+					f.Name, f.Type, cardinalities[f.Cardinality], versionRange(grammars, f.versions))
+			}
+
+			w("},\n")
+		}
+
+		w("VersionRange: %s,\n},\n", versionRange(grammars, n.versions))
+	}
+
+	w("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// goNames returns the Go identifiers of the nodes: the names in CamelCase,
+// with an "Internal" suffix for the types clashing with a constructor (expr
+// and Expr).
+func goNames(nodes map[string]*node) map[string]string {
+	names := make(map[string]string)
+	for name := range nodes {
+		names[name] = camelCase(name)
+	}
+
+	for name := range nodes {
+		if name == names[name] {
+			// a constructor, already in CamelCase
+			continue
+		}
+
+		if _, ok := nodes[names[name]]; ok {
+			names[name] += "Internal"
+		}
+	}
+
+	return names
+}
+
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
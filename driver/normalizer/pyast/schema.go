@@ -0,0 +1,79 @@
+package pyast
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl"
+)
+
+// VersionRange is the range of Python versions, among the ones in Versions,
+// in which a node or a field exists. Both ends are included.
+type VersionRange struct {
+	Since string
+	Until string
+}
+
+// Contains returns true if the version v (as "3.6") is in the range.
+func (r VersionRange) Contains(v string) bool {
+	return compareVersions(r.Since, v) <= 0 && compareVersions(v, r.Until) <= 0
+}
+
+// NodeSchema is the schema of a node type of the Python AST: a constructor
+// (as BinOp), a product type (as arguments) or an abstract sum type (as
+// expr).
+type NodeSchema struct {
+	Name string
+	// Abstract is true for sum types, which have no nodes of their own.
+	Abstract bool
+	// Types are the sum types the constructor belongs to in any version
+	// (Ellipsis is a slice in Python 2 and an expr in Python 3).
+	Types  []string
+	Fields []*FieldSchema
+	VersionRange
+}
+
+// Field returns the field with the given name existing in the version v or
+// nil.
+func (n *NodeSchema) Field(name, v string) *FieldSchema {
+	for _, f := range n.Fields {
+		if f.Name == name && f.Contains(v) {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// FieldSchema is a field of a node type. Fields whose type or cardinality
+// changed between versions appear once per change.
+type FieldSchema struct {
+	Name        string
+	Type        string
+	Cardinality asdl.Cardinality
+	VersionRange
+}
+
+// compareVersions compares two dotted version numbers.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,61 @@
+package pyast
+
+import (
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaVersions(t *testing.T) {
+	require := require.New(t)
+
+	cases := map[string]VersionRange{
+		"Print":     {Since: "2.7", Until: "2.7"},
+		"MatMult":   {Since: "3.6", Until: "3.11"},
+		"NamedExpr": {Since: "3.8", Until: "3.11"},
+		"Num":       {Since: "2.7", Until: "3.6"},
+		"Module":    {Since: "2.7", Until: "3.11"},
+		"slice":     {Since: "2.7", Until: "3.8"},
+	}
+
+	for name, expected := range cases {
+		require.Contains(Schema, name)
+		require.Equal(expected, Schema[name].VersionRange, name)
+	}
+
+	require.True(Schema["slice"].Abstract)
+	require.Equal([]string{"expr", "slice"}, Schema["Ellipsis"].Types)
+	require.Equal([]string{"2.7", "3.6", "3.8", "3.11"}, Versions)
+}
+
+func TestSchemaFields(t *testing.T) {
+	require := require.New(t)
+
+	def := Schema["FunctionDef"]
+	require.Nil(def.Field("returns", "2.7"))
+	require.Equal(&FieldSchema{
+		Name:         "returns",
+		Type:         "expr",
+		Cardinality:  asdl.Optional,
+		VersionRange: VersionRange{Since: "3.6", Until: "3.11"},
+	}, def.Field("returns", "3.6"))
+	require.Equal(asdl.Sequence, def.Field("body", "2.7").Cardinality)
+
+	// a field whose type changed
+	sub := Schema["Subscript"]
+	require.Equal("slice", sub.Field("slice", "3.8").Type)
+	require.Equal("expr", sub.Field("slice", "3.11").Type)
+}
+
+func TestVersionRange(t *testing.T) {
+	require := require.New(t)
+
+	r := VersionRange{Since: "3.6", Until: "3.11"}
+	require.True(r.Contains("3.6"))
+	require.True(r.Contains("3.10"))
+	require.True(r.Contains("3.11"))
+	require.False(r.Contains("3.5"))
+	require.False(r.Contains("2.7"))
+	require.False(r.Contains("3.12"))
+}
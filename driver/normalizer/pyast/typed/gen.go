@@ -0,0 +1,196 @@
+//go:build ignore
+// +build ignore
+
+// gen.go generates typed_generated.go from the pyast.Schema and the
+// conversion of the native AST done by normalizer.ToNode. Run it with
+// `go generate`.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast"
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl"
+)
+
+const output = "typed_generated.go"
+
+// builtins are the ASDL builtin types, stored as tokens or properties.
+var builtins = map[string]bool{
+	"identifier": true,
+	"int":        true,
+	"string":     true,
+	"bytes":      true,
+	"object":     true,
+	"bool":       true,
+	"singleton":  true,
+	"constant":   true,
+}
+
+// methods are the names of the accessors not in CamelCase.
+var methods = map[string]string{
+	"decorator_list": "Decorators",
+	"id":             "ID",
+}
+
+func main() {
+	var names []string
+	for name, n := range pyast.Schema {
+		if !n.Abstract {
+			names = append(names, name)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return typeName(names[i]) < typeName(names[j])
+	})
+
+	var buf bytes.Buffer
+	w := func(format string, args ...interface{}) {
+		fmt.Fprintf(&buf, format, args...)
+	}
+
+	w("// Code generated by gen.go from the pyast.Schema. DO NOT EDIT.\n\n")
+	w("package typed\n\n")
+	w("import \"gopkg.in/bblfsh/sdk.v1/uast\"\n\n")
+
+	w("var wrappers = map[string]func(*uast.Node) Node{\n")
+	for _, name := range names {
+		w("%q: func(n *uast.Node) Node { return &%s{n} },\n", name, typeName(name))
+	}
+
+	w("}\n\n")
+	w("// Visitor has a method for each node type, called by Walk.\n")
+	w("type Visitor interface {\n")
+	for _, name := range names {
+		w("Visit%[1]s(*%[1]s)\n", typeName(name))
+	}
+
+	w("VisitOther(*Other)\n}\n\n")
+	w("// BaseVisitor is a Visitor doing nothing, to be embedded in visitors\n")
+	w("// handling only some node types.\n")
+	w("type BaseVisitor struct{}\n\n")
+	for _, name := range names {
+		w("// Visit%[1]s implements Visitor.\n", typeName(name))
+		w("func (BaseVisitor) Visit%[1]s(*%[1]s) {}\n\n", typeName(name))
+	}
+
+	w("// VisitOther implements Visitor.\n")
+	w("func (BaseVisitor) VisitOther(*Other) {}\n\n")
+	for _, name := range names {
+		writeType(w, name, pyast.Schema[name])
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeType(w func(string, ...interface{}), name string, n *pyast.NodeSchema) {
+	typ := typeName(name)
+	fields := newestFields(n)
+	var decls []string
+	for _, f := range fields {
+		decls = append(decls, fmt.Sprintf("%s%s %s", f.Type, f.Cardinality, f.Name))
+	}
+
+	decl := name
+	if len(decls) > 0 {
+		decl += "(" + strings.Join(decls, ", ") + ")"
+	}
+
+	w("// %s is the view of the %s nodes: %s.\n", typ, name, decl)
+	w("type %s struct{ *uast.Node }\n\n", typ)
+	w("// UAST implements Node.\n")
+	w("func (n *%s) UAST() *uast.Node { return n.Node }\n\n", typ)
+	w("// Accept implements Node.\n")
+	w("func (n *%[1]s) Accept(v Visitor) { v.Visit%[1]s(n) }\n\n", typ)
+	for _, f := range fields {
+		writeAccessor(w, name, f)
+	}
+}
+
+func writeAccessor(w func(string, ...interface{}), node string, f *pyast.FieldSchema) {
+	typ, method := typeName(node), methodName(f.Name)
+	seq := f.Cardinality == asdl.Sequence
+	w("// %s returns the %s%s %s.\n", method, f.Type, f.Cardinality, f.Name)
+	switch {
+	case builtins[f.Type] && seq:
+		w("func (n *%s) %s() []string {\n", typ, method)
+		w("return tokens(list(n.Node, %q))\n}\n\n", f.Name)
+	case builtins[f.Type]:
+		w("func (n *%s) %s() string {\n", typ, method)
+		switch {
+		case normalizer.ToNode.PromotedPropertyStrings[node][f.Name]:
+			w("return n.Properties[%q]\n}\n\n", node+"."+f.Name)
+		case normalizer.ToNode.TokenKeys[f.Name]:
+			w("return n.Token\n}\n\n")
+		default:
+			w("return n.Properties[%q]\n}\n\n", f.Name)
+		}
+	case pyast.Schema[f.Type].Abstract && seq:
+		w("func (n *%s) %s() []Node {\n", typ, method)
+		w("return wrapAll(list(n.Node, %q))\n}\n\n", f.Name)
+	case pyast.Schema[f.Type].Abstract:
+		w("func (n *%s) %s() Node {\n", typ, method)
+		w("return Wrap(child(n.Node, %q))\n}\n\n", f.Name)
+	case seq:
+		elem := typeName(f.Type)
+		w("func (n *%s) %s() []*%s {\n", typ, method, elem)
+		w("var r []*%s\n", elem)
+		w("for _, c := range ofType(list(n.Node, %q), %q) {\n", f.Name, f.Type)
+		w("r = append(r, &%s{c})\n}\n\nreturn r\n}\n\n", elem)
+	default:
+		elem := typeName(f.Type)
+		w("func (n *%s) %s() *%s {\n", typ, method, elem)
+		w("if c := child(n.Node, %q); c != nil {\nreturn &%s{c}\n}\n\n", f.Name, elem)
+		w("return nil\n}\n\n")
+	}
+}
+
+// newestFields returns the fields of the newest version of the node.
+func newestFields(n *pyast.NodeSchema) []*pyast.FieldSchema {
+	var fields []*pyast.FieldSchema
+	for _, f := range n.Fields {
+		if f.Until == n.Until {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+func typeName(name string) string {
+	return camelCase(name)
+}
+
+func methodName(field string) string {
+	if m, ok := methods[field]; ok {
+		return m
+	}
+
+	return camelCase(field)
+}
+
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
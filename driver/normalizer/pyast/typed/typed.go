@@ -0,0 +1,115 @@
+// Package typed is a typed view over the Python UAST: every node type of the
+// Python grammar has a wrapper with one accessor per field, as
+// `(*FunctionDef).Body()`, generated from the pyast.Schema.
+//
+// The accessors follow the newest grammar in pyast.Versions; fields of older
+// versions with a different type are not available.
+package typed
+
+//go:generate go run gen.go
+
+import (
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Node is the typed view of a node of the Python UAST.
+type Node interface {
+	// UAST returns the wrapped node.
+	UAST() *uast.Node
+	// Accept calls the method of the visitor for the type of the node.
+	Accept(v Visitor)
+}
+
+// Other is the view of the nodes whose type is not in the Python grammar: the
+// ones added by the native driver (as NoneLiteral) or the normalizer, and the
+// promoted property lists.
+type Other struct{ *uast.Node }
+
+// UAST implements Node.
+func (n *Other) UAST() *uast.Node { return n.Node }
+
+// Accept implements Node.
+func (n *Other) Accept(v Visitor) { v.VisitOther(n) }
+
+// Wrap returns the typed view of a node, nil for a nil node.
+func Wrap(n *uast.Node) Node {
+	if n == nil {
+		return nil
+	}
+
+	if w, ok := wrappers[n.InternalType]; ok {
+		return w(n)
+	}
+
+	return &Other{n}
+}
+
+// Walk visits the node and all its descendants in pre-order.
+func Walk(v Visitor, n *uast.Node) {
+	if n == nil {
+		return
+	}
+
+	Wrap(n).Accept(v)
+	for _, c := range n.Children {
+		Walk(v, c)
+	}
+}
+
+func wrapAll(nodes []*uast.Node) []Node {
+	var ws []Node
+	for _, n := range nodes {
+		ws = append(ws, Wrap(n))
+	}
+
+	return ws
+}
+
+// child returns the child of n for a single field.
+func child(n *uast.Node, field string) *uast.Node {
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] == field {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// list returns the nodes of a list field, either promoted to its own node
+// (as FunctionDef.body) or as children of n.
+func list(n *uast.Node, field string) []*uast.Node {
+	promoted := n.InternalType + "." + field
+	var nodes []*uast.Node
+	for _, c := range n.Children {
+		switch {
+		case c.InternalType == promoted:
+			return c.Children
+		case c.Properties[uast.InternalRoleKey] == field:
+			nodes = append(nodes, c)
+		}
+	}
+
+	return nodes
+}
+
+// ofType returns the nodes with the given internal type.
+func ofType(nodes []*uast.Node, typ string) []*uast.Node {
+	var r []*uast.Node
+	for _, n := range nodes {
+		if n.InternalType == typ {
+			r = append(r, n)
+		}
+	}
+
+	return r
+}
+
+func tokens(nodes []*uast.Node) []string {
+	var tks []string
+	for _, n := range nodes {
+		tks = append(tks, n.Token)
+	}
+
+	return tks
+}
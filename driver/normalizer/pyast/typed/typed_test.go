@@ -0,0 +1,106 @@
+package typed
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func fixture(t *testing.T, name string) *uast.Node {
+	require := require.New(t)
+
+	native, err := ioutil.ReadFile(filepath.Join("..", "..", "..", "..", "fixtures", name+".native"))
+	require.NoError(err)
+
+	var resp struct {
+		AST map[string]interface{} `json:"ast"`
+	}
+
+	require.NoError(json.Unmarshal(native, &resp))
+	n, err := normalizer.ToNode.ToNode(resp.AST)
+	require.NoError(err)
+	return n
+}
+
+func TestFunctionDef(t *testing.T) {
+	require := require.New(t)
+
+	m, ok := Wrap(fixture(t, "u2_func_annotated.py")).(*Module)
+	require.True(ok)
+
+	body := m.Body()
+	require.Len(body, 1)
+	f, ok := body[0].(*FunctionDef)
+	require.True(ok)
+	require.Equal("testfnc1", f.Name())
+	require.Empty(f.Decorators())
+
+	var names []string
+	for _, a := range f.Args().Args() {
+		names = append(names, a.Arg())
+		require.IsType(&Name{}, a.Annotation())
+	}
+
+	require.Equal([]string{"a", "b", "c", "d"}, names)
+	require.Nil(f.Args().Vararg())
+
+	returns, ok := f.Returns().(*Name)
+	require.True(ok)
+	require.Equal("str", returns.ID())
+
+	require.Len(f.Body(), 1)
+	require.IsType(&Pass{}, f.Body()[0])
+}
+
+func TestClassDef(t *testing.T) {
+	require := require.New(t)
+
+	m := Wrap(fixture(t, "u2_class_metaclass_python3.py")).(*Module)
+	require.Len(m.Body(), 2)
+
+	c := m.Body()[1].(*ClassDef)
+	require.Equal("cls1", c.Name())
+	require.Empty(c.Bases())
+
+	kws := c.Keywords()
+	require.Len(kws, 1)
+	require.Equal("metaclass", kws[0].Arg())
+	require.Equal("meta", kws[0].Value().(*Name).ID())
+}
+
+func TestPromotedStrings(t *testing.T) {
+	require := require.New(t)
+
+	m := Wrap(fixture(t, "u2_import_rename.py")).(*Module)
+	imp := m.Body()[0].(*Import)
+	require.Equal("a", imp.Names()[0].Name())
+	require.Equal("b", imp.Names()[0].Asname())
+
+	from := m.Body()[1].(*ImportFrom)
+	require.Equal("c", from.Module())
+}
+
+type counter struct {
+	BaseVisitor
+	names []string
+	other int
+}
+
+func (c *counter) VisitName(n *Name) { c.names = append(c.names, n.ID()) }
+
+func (c *counter) VisitOther(*Other) { c.other++ }
+
+func TestWalk(t *testing.T) {
+	require := require.New(t)
+
+	v := &counter{}
+	Walk(v, fixture(t, "other_statements.py"))
+	require.Equal([]string{"a", "b", "a", "a", "b", "b"}, v.names)
+	// FunctionDef.body
+	require.Equal(1, v.other)
+}
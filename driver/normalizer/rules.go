@@ -0,0 +1,252 @@
+package normalizer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ghodss/yaml"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	. "gopkg.in/bblfsh/sdk.v1/uast/ann"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// RulesEnv is the environment variable read by the driver with the path of a
+// rules file to overlay on top of the AnnotationRules.
+const RulesEnv = "PYTHON_DRIVER_RULES"
+
+// ErrInvalidRules is returned when a rules file can't be loaded.
+var ErrInvalidRules = errors.NewKind("invalid annotation rules in %s: %s")
+
+// RulesFile is a declarative file of annotation rules, in YAML, JSON or TOML:
+//
+//  rules:
+//    - match: {type: FunctionDef}
+//      children:
+//        - match: {role: decorator_list}
+//          descendants:
+//            - match: {type: Name, token: route}
+//              roles: [Call, Incomplete]
+//
+// The rules of the file are matched against every node of the tree.
+type RulesFile struct {
+	Rules []*RuleSpec `json:"rules" toml:"rules"`
+}
+
+// RuleSpec is the declaration of an `ann.Rule`: the roles are added to the
+// nodes matching all the predicates in Match, then the nested rules are
+// applied to the node itself, its children or its descendants. (The key is not
+// "on" since YAML reads it as a boolean.)
+type RuleSpec struct {
+	Match       *PredicateSpec `json:"match" toml:"match"`
+	Roles       []string       `json:"roles" toml:"roles"`
+	Self        []*RuleSpec    `json:"self" toml:"self"`
+	Children    []*RuleSpec    `json:"children" toml:"children"`
+	Descendants []*RuleSpec    `json:"descendants" toml:"descendants"`
+}
+
+// PredicateSpec is the declaration of the predicates of a rule, all of them
+// have to match. An empty PredicateSpec matches any node.
+type PredicateSpec struct {
+	// Type is the internal type of the node.
+	Type string `json:"type" toml:"type"`
+	// Role is the internal role of the node.
+	Role string `json:"role" toml:"role"`
+	// Token is the token of the node.
+	Token string `json:"token" toml:"token"`
+	// Properties are the values of properties of the node.
+	Properties map[string]string `json:"properties" toml:"properties"`
+	// Child matches nodes with a child matching it.
+	Child *PredicateSpec `json:"child" toml:"child"`
+	// AnyOf matches nodes matching any of its predicates.
+	AnyOf []*PredicateSpec `json:"anyOf" toml:"anyOf"`
+	// Not matches the nodes not matching it.
+	Not *PredicateSpec `json:"not" toml:"not"`
+}
+
+var roleNames = make(map[string]uast.Role)
+
+func init() {
+	for _, v := range uast.Role_value {
+		r := uast.Role(v)
+		roleNames[strings.ToLower(r.String())] = r
+	}
+}
+
+// LoadRules reads a rules file, in YAML or JSON (.yaml, .yml or .json) or TOML
+// (.toml) by its extension, and returns its rules.
+func LoadRules(path string) (*Rule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f RulesFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		err = yaml.Unmarshal(data, &f)
+	case ".toml":
+		err = toml.Unmarshal(data, &f)
+	default:
+		err = fmt.Errorf("unknown format %q", filepath.Ext(path))
+	}
+
+	if err != nil {
+		return nil, ErrInvalidRules.New(path, err)
+	}
+
+	r, err := f.Rule()
+	if err != nil {
+		return nil, ErrInvalidRules.New(path, err)
+	}
+
+	return r, nil
+}
+
+// Rule returns the rules of the file, matching every node of the tree.
+func (f *RulesFile) Rule() (*Rule, error) {
+	rules, err := buildRules(f.Rules, "rules")
+	if err != nil {
+		return nil, err
+	}
+
+	return On(Any).DescendantsOrSelf(rules...), nil
+}
+
+// Overlay returns a rule applying the base rules and then the overlays.
+func Overlay(base *Rule, overlays ...*Rule) *Rule {
+	return On(Any).Self(append([]*Rule{base}, overlays...)...)
+}
+
+func buildRules(specs []*RuleSpec, path string) ([]*Rule, error) {
+	var rules []*Rule
+	for i, s := range specs {
+		r, err := s.build(fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules, nil
+}
+
+func (s *RuleSpec) build(path string) (*Rule, error) {
+	pred, err := s.Match.build(path + ".match")
+	if err != nil {
+		return nil, err
+	}
+
+	r := On(pred)
+	if len(s.Roles) > 0 {
+		var rs []uast.Role
+		for _, name := range s.Roles {
+			role, ok := roleNames[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("%s.roles: unknown role %q", path, name)
+			}
+
+			rs = append(rs, role)
+		}
+
+		r = r.Roles(rs...)
+	}
+
+	for _, nested := range []struct {
+		name  string
+		specs []*RuleSpec
+		add   func(...*Rule) *Rule
+	}{
+		{"self", s.Self, r.Self},
+		{"children", s.Children, r.Children},
+		{"descendants", s.Descendants, r.Descendants},
+	} {
+		if len(nested.specs) == 0 {
+			continue
+		}
+
+		rules, err := buildRules(nested.specs, path+"."+nested.name)
+		if err != nil {
+			return nil, err
+		}
+
+		nested.add(rules...)
+	}
+
+	return r, nil
+}
+
+func (s *PredicateSpec) build(path string) (Predicate, error) {
+	if s == nil {
+		return Any, nil
+	}
+
+	var preds []Predicate
+	if s.Type != "" {
+		preds = append(preds, HasInternalType(s.Type))
+	}
+
+	if s.Role != "" {
+		preds = append(preds, HasInternalRole(s.Role))
+	}
+
+	if s.Token != "" {
+		preds = append(preds, HasToken(s.Token))
+	}
+
+	// sorted, so the rules and their String are the same on every load
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	for _, k := range keys {
+		preds = append(preds, HasProperty(k, s.Properties[k]))
+	}
+
+	if s.Child != nil {
+		p, err := s.Child.build(path + ".child")
+		if err != nil {
+			return nil, err
+		}
+
+		preds = append(preds, HasChild(p))
+	}
+
+	if len(s.AnyOf) > 0 {
+		var alts []Predicate
+		for i, spec := range s.AnyOf {
+			p, err := spec.build(fmt.Sprintf("%s.anyOf[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+
+			alts = append(alts, p)
+		}
+
+		preds = append(preds, Or(alts...))
+	}
+
+	if s.Not != nil {
+		p, err := s.Not.build(path + ".not")
+		if err != nil {
+			return nil, err
+		}
+
+		preds = append(preds, Not(p))
+	}
+
+	switch len(preds) {
+	case 0:
+		return Any, nil
+	case 1:
+		return preds[0], nil
+	}
+
+	return And(preds...), nil
+}
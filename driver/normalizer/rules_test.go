@@ -0,0 +1,152 @@
+package normalizer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+const rulesYAML = `
+rules:
+  - match: {type: FunctionDef}
+    children:
+      - match: {role: decorator_list}
+        descendants:
+          - match: {type: Name, token: route}
+            roles: [Call, Incomplete]
+  - match:
+      anyOf: [{type: Str}, {type: Num}]
+      not: {properties: {internalRole: annotation}}
+    roles: [LITERAL]
+`
+
+const rulesTOML = `
+[[rules]]
+match = { type = "FunctionDef" }
+
+  [[rules.children]]
+  match = { role = "decorator_list" }
+
+    [[rules.children.descendants]]
+    match = { type = "Name", token = "route" }
+    roles = ["Call", "Incomplete"]
+
+[[rules]]
+roles = ["Literal"]
+[rules.match]
+anyOf = [{ type = "Str" }, { type = "Num" }]
+not = { properties = { internalRole = "annotation" } }
+`
+
+// writeRules writes the rules to a file in a new temporary directory,
+// returning its path and a function removing the directory.
+func writeRules(t *testing.T, name, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "rules")
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		os.RemoveAll(dir)
+		require.NoError(t, err)
+	}
+
+	return path, func() { os.RemoveAll(dir) }
+}
+
+// decorated returns the UAST of:
+//
+//  @route("/")
+//  def f(x: "int"): pass
+func decorated() (module, route, str, ann *uast.Node) {
+	route = &uast.Node{InternalType: "Name", Token: "route", Properties: map[string]string{}}
+	str = &uast.Node{InternalType: "Str", Token: "/", Properties: map[string]string{}}
+	ann = &uast.Node{InternalType: "Str", Token: "int",
+		Properties: map[string]string{uast.InternalRoleKey: "annotation"}}
+
+	module = &uast.Node{InternalType: "Module", Children: []*uast.Node{{
+		InternalType: "FunctionDef",
+		Properties:   map[string]string{},
+		Children: []*uast.Node{{
+			InternalType: "Call",
+			Properties:   map[string]string{uast.InternalRoleKey: "decorator_list"},
+			Children:     []*uast.Node{route, str},
+		}, ann},
+	}}}
+
+	return
+}
+
+func TestLoadRules(t *testing.T) {
+	for _, f := range []struct{ name, content string }{
+		{"rules.yaml", rulesYAML},
+		{"rules.toml", rulesTOML},
+	} {
+		require := require.New(t)
+
+		path, cleanup := writeRules(t, f.name, f.content)
+		defer cleanup()
+
+		overlay, err := LoadRules(path)
+		require.NoError(err, f.name)
+
+		module, route, str, ann := decorated()
+		require.NoError(overlay.Apply(module))
+		require.Equal([]uast.Role{uast.Call, uast.Incomplete}, route.Roles, f.name)
+		require.Equal([]uast.Role{uast.Literal}, str.Roles, f.name)
+		require.Len(ann.Roles, 0, f.name)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	require := require.New(t)
+
+	path, cleanup := writeRules(t, "rules.yml", rulesYAML)
+	defer cleanup()
+
+	overlay, err := LoadRules(path)
+	require.NoError(err)
+
+	module, route, str, _ := decorated()
+	require.NoError(Overlay(AnnotationRules, overlay).Apply(module))
+	require.Contains(module.Roles, uast.Module)
+	require.Contains(route.Roles, uast.Identifier)
+	require.Contains(route.Roles, uast.Incomplete)
+	require.Contains(str.Roles, uast.String)
+	require.Contains(str.Roles, uast.Literal)
+}
+
+func TestLoadRulesErrors(t *testing.T) {
+	require := require.New(t)
+
+	for name, content := range map[string]string{
+		"unknown_role.yaml": "rules: [{roles: [Banana]}]",
+		"invalid.toml":      "rules = [",
+		"rules.ini":         "",
+	} {
+		path, cleanup := writeRules(t, name, content)
+		defer cleanup()
+
+		_, err := LoadRules(path)
+		require.True(ErrInvalidRules.Is(err), name)
+	}
+}
+
+func TestPredicateSpecDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	spec := &PredicateSpec{Type: "Name", Properties: map[string]string{
+		"a": "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "6", "g": "7", "h": "8",
+	}}
+
+	first, err := spec.build("match")
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		p, err := spec.build("match")
+		require.NoError(err)
+		require.Equal(first.String(), p.String())
+	}
+}
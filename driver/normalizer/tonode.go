@@ -1,6 +1,10 @@
 package normalizer
 
-import "gopkg.in/bblfsh/sdk.v1/uast"
+import (
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast"
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
 
 // ToNode is an instance of `uast.ObjectToNode`, defining how to transform an
 // into a UAST (`uast.Node`).
@@ -70,26 +74,105 @@ var ToNode = &uast.ObjectToNode{
 		"Yield":     "yield",
 	},
 	PromoteAllPropertyLists: false,
-	PromotedPropertyLists: map[string]map[string]bool{
-		"If":       {"body": true, "orelse": true},
-		"For":      {"body": true, "orelse": true},
-		"AsyncFor": {"body": true, "orelse": true},
-		"While":    {"body": true, "orelse": true},
-		"Compare":  {"comparators": true, "ops": true},
-		// FIXME: check call.keywords
-		//"Call"                    : { "args": true, "keywords": true},
-		"With":        {"body": true, "items": true},
-		"FunctionDef": {"body": true, "decorator_list": true},
-		"Lambda":      {"body": true},
-		"arguments":   {"defaults": true},
-		"Try":         {"body": true, "orelse": true, "finalbody": true},
-		"Raise":       {"args": true},
-		"ClassDef":    {"body": true, "bases": true, "decorator_list": true, "keywords": true},
-	},
+	PromotedPropertyLists:   promotedPropertyLists(pyast.Schema, listOverrides),
 	PromotedPropertyStrings: map[string]map[string]bool{
 		"alias":         {"asname": true},
 		"ImportFrom":    {"module": true},
 		"ExceptHandler": {"name": true},
 	},
 	// FIXME: test[ast_type=Compare].comparators is a list?? (should be "right")
+	OnToNode: recordVersion,
+}
+
+// PythonVersionKey is the property set on the Module node with the major
+// version of the Python grammar used to parse the file, "2" or "3".
+const PythonVersionKey = "pythonVersion"
+
+// grammarVersions are the major versions of the native ASTs, by their root
+// key.
+var grammarVersions = map[string]string{
+	"PY2AST": "2",
+	"PY3AST": "3",
+}
+
+// recordVersion is the OnToNode hook of ToNode, setting the PythonVersionKey
+// of the root node from the key of the native AST, without modifying it.
+func recordVersion(ast interface{}) (interface{}, error) {
+	m, ok := ast.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return ast, nil
+	}
+
+	for key, root := range m {
+		module, ok := root.(map[string]interface{})
+		version, known := grammarVersions[key]
+		if !ok || !known {
+			return ast, nil
+		}
+
+		copied := make(map[string]interface{}, len(module)+1)
+		for k, v := range module {
+			copied[k] = v
+		}
+
+		copied[PythonVersionKey] = version
+		return map[string]interface{}{key: copied}, nil
+	}
+
+	return ast, nil
+}
+
+// listOverrides are the exceptions to the promotion of the list fields of the
+// grammar to their own node: false keeps the elements as children of the node,
+// true promotes a field not in the pyast.Schema.
+var listOverrides = map[string]map[string]bool{
+	// statements of the file
+	"Module":      {"body": false},
+	"Interactive": {"body": false},
+	"Suite":       {"body": false},
+	// elements annotated by their internal role
+	"Assign":    {"targets": false},
+	"Dict":      {"keys": false, "values": false},
+	"Call":      {"args": false, "keywords": false},
+	"arguments": {"args": false, "kwonlyargs": false, "posonlyargs": false},
+	"Print":     {"values": false},
+	// operands of the node, which already groups them
+	"Delete":     {"targets": false},
+	"BoolOp":     {"values": false},
+	"List":       {"elts": false},
+	"Tuple":      {"elts": false},
+	"Set":        {"elts": false},
+	"JoinedStr":  {"values": false},
+	"ExtSlice":   {"dims": false},
+	"Import":     {"names": false},
+	"ImportFrom": {"names": false},
+}
+
+// promotedPropertyLists returns the list fields (with * cardinality) of nodes
+// in the schema, after applying the overrides.
+func promotedPropertyLists(schema map[string]*pyast.NodeSchema, overrides map[string]map[string]bool) map[string]map[string]bool {
+	lists := make(map[string]map[string]bool)
+	set := func(node, field string, promote bool) {
+		if lists[node] == nil {
+			lists[node] = make(map[string]bool)
+		}
+
+		lists[node][field] = promote
+	}
+
+	for name, n := range schema {
+		for _, f := range n.Fields {
+			if f.Cardinality == asdl.Sequence && schema[f.Type] != nil {
+				set(name, f.Name, true)
+			}
+		}
+	}
+
+	for node, fields := range overrides {
+		for field, promote := range fields {
+			set(node, field, promote)
+		}
+	}
+
+	return lists
 }
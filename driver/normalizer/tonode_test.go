@@ -3,6 +3,8 @@ package normalizer
 import (
 	"testing"
 
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast"
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,3 +18,71 @@ func TestNativeToNoder(t *testing.T) {
 	require.NoError(err)
 	require.NotNil(n)
 }
+
+func TestPromotedPropertyLists(t *testing.T) {
+	require := require.New(t)
+
+	lists := ToNode.PromotedPropertyLists
+	require.True(lists["AsyncFunctionDef"]["body"])
+	require.True(lists["AsyncWith"]["body"])
+	require.True(lists["Try"]["handlers"])
+	require.True(lists["Compare"]["ops"])
+	require.False(lists["Module"]["body"])
+	require.False(lists["Call"]["keywords"])
+
+	// list fields of builtin types are properties
+	require.NotContains(lists["Global"], "names")
+
+	// every list field of nodes is either promoted or overridden
+	for name, n := range pyast.Schema {
+		for _, f := range n.Fields {
+			if f.Cardinality != asdl.Sequence || pyast.Schema[f.Type] == nil {
+				continue
+			}
+
+			require.Contains(lists[name], f.Name, "%s.%s", name, f.Name)
+			if _, ok := listOverrides[name][f.Name]; !ok {
+				require.True(lists[name][f.Name], "%s.%s", name, f.Name)
+			}
+		}
+	}
+}
+
+func TestPromotedPropertyListsOverrides(t *testing.T) {
+	require := require.New(t)
+
+	schema := map[string]*pyast.NodeSchema{
+		"stmt": {Name: "stmt", Abstract: true},
+		"If": {Name: "If", Fields: []*pyast.FieldSchema{
+			{Name: "test", Type: "stmt"},
+			{Name: "body", Type: "stmt", Cardinality: asdl.Sequence},
+			{Name: "orelse", Type: "stmt", Cardinality: asdl.Sequence},
+			{Name: "names", Type: "identifier", Cardinality: asdl.Sequence},
+		}},
+	}
+
+	lists := promotedPropertyLists(schema, map[string]map[string]bool{
+		"If":     {"orelse": false},
+		"Native": {"items": true},
+	})
+
+	require.Equal(map[string]map[string]bool{
+		"If":     {"body": true, "orelse": false},
+		"Native": {"items": true},
+	}, lists)
+}
+
+func TestToNodePythonVersion(t *testing.T) {
+	require := require.New(t)
+
+	for key, version := range map[string]string{"PY2AST": "2", "PY3AST": "3"} {
+		ast := map[string]interface{}{key: map[string]interface{}{"ast_type": "Module"}}
+		n, err := ToNode.ToNode(ast)
+		require.NoError(err)
+		require.Equal(version, n.Properties[PythonVersionKey])
+
+		// the native AST isn't modified
+		_, ok := ast[key].(map[string]interface{})[PythonVersionKey]
+		require.False(ok)
+	}
+}
@@ -0,0 +1,247 @@
+package normalizer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/pytypes"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// TypeCommentKey is the property set to "true" on the annotations parsed from
+// a type comment.
+const TypeCommentKey = "typeComment"
+
+var (
+	typeComment = regexp.MustCompile(`^#\s*type:\s*`)
+	typeIgnore  = regexp.MustCompile(`^ignore\b`)
+)
+
+// TypeCommentParser is a `transformer.Tranformer` that parses the PEP 484 type
+// comments and adds them to the nodes they annotate, with the same shape that
+// the native AST has for annotations:
+//
+// - `x = []  # type: List[int]` adds an "annotation" child to the Assign (also
+// for the For and With statements).
+// - `def f(a, b):  # type: (int, str) -> bool` adds an "annotation" child to
+// every argument and a "returns" child to the function, as well as the
+// per-argument comments of functions declared in several lines. The variable
+// arguments of Python 2, which are properties of the arguments node, are
+// annotated with a "varargannotation" or "kwargannotation" child of it, as in
+// the AST of Python 3.0 to 3.3.
+//
+// The comments themselves are kept, and nodes already annotated are left as
+// they are.
+type TypeCommentParser struct{}
+
+// NewTypeCommentParser returns a new TypeCommentParser.
+func NewTypeCommentParser() *TypeCommentParser {
+	return &TypeCommentParser{}
+}
+
+// sourceText is a piece of text found at the given line and column of the
+// source.
+type sourceText struct {
+	text      string
+	line, col int
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *TypeCommentParser) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	b := &binder{code: code, idx: newLineIndex([]byte(code))}
+	comments := make(map[int]*sourceText)
+
+	var targets []*uast.Node
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		n := p.Node()
+		switch n.InternalType {
+		case "NoopLine", "SameLineNoops", Pragma:
+			if c := b.typeComment(n); c != nil && comments[c.line] == nil {
+				comments[c.line] = c
+			}
+		case "Assign", "For", "AsyncFor", "With", "AsyncWith",
+			"FunctionDef", "AsyncFunctionDef":
+			targets = append(targets, n)
+		}
+	}
+
+	if len(comments) == 0 {
+		return nil
+	}
+
+	for _, n := range targets {
+		switch n.InternalType {
+		case "FunctionDef", "AsyncFunctionDef":
+			annotateFunction(n, comments)
+		case "Assign":
+			_, end := span(n)
+			annotate(n, "annotation", comments[end])
+		default:
+			annotate(n, "annotation", comments[line(n)])
+		}
+	}
+
+	return nil
+}
+
+// typeComment returns the type expression of a comment node, if it's a type
+// comment.
+func (b *binder) typeComment(n *uast.Node) *sourceText {
+	l := line(n)
+	src := b.idx.Line(b.code, l)
+
+	var col int
+	switch {
+	case n.InternalType == "SameLineNoops" || n.Properties[ScopeKey] == PragmaLine:
+		text := strings.TrimSuffix(strings.TrimPrefix(n.Token, "["), "]")
+		col = strings.LastIndex(src, text)
+	case b.isComment(n):
+		col = strings.Index(src, "#")
+	default:
+		return nil
+	}
+
+	if col < 0 {
+		return nil
+	}
+
+	text := strings.TrimRight(src[col:], "\r\n")
+	m := typeComment.FindStringIndex(text)
+	if m == nil || typeIgnore.MatchString(text[m[1]:]) {
+		return nil
+	}
+
+	return &sourceText{text: text[m[1]:], line: l, col: col + m[1] + 1}
+}
+
+// parseType parses a type expression and converts it to a node.
+func parseType(c *sourceText) *uast.Node {
+	p := newTypeParser(c.text, c.line, c.col)
+	obj, err := p.Expr()
+	if err != nil || !p.done() {
+		return nil
+	}
+
+	return typeNode(obj)
+}
+
+func typeNode(obj map[string]interface{}) *uast.Node {
+	// ToNode expects the root node wrapped in an object, as in the responses of
+	// the native driver
+	n, err := ToNode.ToNode(map[string]interface{}{"type": obj})
+	if err != nil {
+		return nil
+	}
+
+	return n
+}
+
+// annotate adds the type of a type comment to n with the given internal role,
+// unless it already has one.
+func annotate(n *uast.Node, role string, c *sourceText) {
+	if c == nil || hasInternalRole(n, role) {
+		return
+	}
+
+	if t := parseType(c); t != nil {
+		addTypeComment(n, role, t)
+	}
+}
+
+func addTypeComment(n *uast.Node, role string, t *uast.Node) {
+	t.Properties[uast.InternalRoleKey] = role
+	t.Properties[TypeCommentKey] = "true"
+	n.Children = append(n.Children, t)
+}
+
+func hasInternalRole(n *uast.Node, role string) bool {
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+func annotateFunction(n *uast.Node, comments map[int]*sourceText) {
+	var (
+		params []pytypes.Param
+		body   = line(n)
+	)
+
+	for _, c := range n.Children {
+		switch {
+		case c.InternalType == "arguments":
+			params = pytypes.Params(c)
+		case strings.HasSuffix(c.InternalType, ".body"):
+			if start, _ := span(c); start > body {
+				body = start
+			}
+		}
+	}
+
+	// the signature comment is either at the end of the def line or right
+	// after it, before the body
+	var sig *signature
+	for l := line(n); l == line(n) || l < body; l++ {
+		c := comments[l]
+		if c == nil || !strings.HasPrefix(c.text, "(") {
+			continue
+		}
+
+		p := newTypeParser(c.text, c.line, c.col)
+		if s, err := p.Signature(); err == nil && p.done() {
+			sig = s
+			break
+		}
+	}
+
+	if sig != nil {
+		if t := typeNode(sig.returns); t != nil && !hasInternalRole(n, "returns") {
+			addTypeComment(n, "returns", t)
+		}
+
+		// self and cls are omitted in the type comments of methods
+		if len(sig.args) == len(params)-1 {
+			params = params[1:]
+		}
+
+		if len(sig.args) == len(params) {
+			for i, p := range params {
+				if t := typeNode(sig.args[i]); t != nil && !hasInternalRole(p.Node, p.Role) {
+					addTypeComment(p.Node, p.Role, t)
+				}
+			}
+		}
+	}
+
+	// per-argument comments, after the last argument of each line, except
+	// for the variable arguments of Python 2, which have no position
+	for i, p := range params {
+		if p.Role != "annotation" ||
+			i+1 < len(params) && line(params[i+1].Node) == line(p.Node) {
+			continue
+		}
+
+		if c := comments[line(p.Node)]; c != nil && line(p.Node) < body {
+			annotate(p.Node, "annotation", c)
+		}
+	}
+}
+
+// arguments returns the arguments of a function in declaration order: the arg
+// nodes, or the Name and Tuple nodes of Python 2.
+func arguments(n *uast.Node) []*uast.Node {
+	var args []*uast.Node
+	for _, p := range pytypes.Params(n) {
+		if p.Node != n {
+			args = append(args, p.Node)
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,272 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/pytypes"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+const typeCommentsCode = `x = []  # type: List[int]
+def f(self, a, *b):  # type: (int, *str) -> Optional[bool]
+    pass
+`
+
+// typeCommentsNative is the native AST of typeCommentsCode.
+const typeCommentsNative = `{"PY3AST": {
+  "ast_type": "Module",
+  "body": [{
+    "ast_type": "Assign", "lineno": 1, "col_offset": 1,
+    "targets": [{"ast_type": "Name", "id": "x", "ctx": "Store",
+      "lineno": 1, "col_offset": 1, "end_lineno": 1, "end_col_offset": 1}],
+    "value": {"ast_type": "List", "elts": [], "ctx": "Load", "lineno": 1, "col_offset": 5},
+    "noops_sameline": {"ast_type": "SameLineNoops", "noop_line": ["# type: List[int]"],
+      "lineno": 1, "col_offset": 8, "end_lineno": 1, "end_col_offset": 25}
+  }, {
+    "ast_type": "FunctionDef", "name": "f", "lineno": 2, "col_offset": 5,
+    "args": {"ast_type": "arguments",
+      "args": [
+        {"ast_type": "arg", "arg": "self", "lineno": 2, "col_offset": 7},
+        {"ast_type": "arg", "arg": "a", "lineno": 2, "col_offset": 13}
+      ],
+      "vararg": {"ast_type": "arg", "arg": "b", "lineno": 2, "col_offset": 17},
+      "defaults": [], "kw_defaults": [], "kwonlyargs": []},
+    "body": [{"ast_type": "Pass", "lineno": 3, "col_offset": 5}],
+    "decorator_list": [],
+    "noops_sameline": {"ast_type": "SameLineNoops",
+      "noop_line": ["# type: (int, *str) -> Optional[bool]"],
+      "lineno": 2, "col_offset": 22, "end_lineno": 2, "end_col_offset": 59}
+  }]
+}}`
+
+func child(n *uast.Node, role string) *uast.Node {
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] == role {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func TestTypeCommentParser(t *testing.T) {
+	require := require.New(t)
+
+	var obj map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(typeCommentsNative), &obj))
+
+	n, err := ToNode.ToNode(obj)
+	require.NoError(err)
+
+	for _, t := range []interface {
+		Do(string, protocol.Encoding, *uast.Node) error
+	}{NewCommentBinder(), NewPragmaClassifier(), NewTypeCommentParser()} {
+		require.NoError(t.Do(typeCommentsCode, protocol.UTF8, n))
+	}
+
+	require.NoError(AnnotationRules.Apply(n))
+
+	// x = []  # type: List[int]
+	ann := child(n.Children[0], "annotation")
+	require.NotNil(ann)
+	require.Equal("Subscript", ann.InternalType)
+	require.Equal("true", ann.Properties[TypeCommentKey])
+	require.Contains(ann.Roles, uast.Annotation)
+	require.Equal("List", child(ann, "value").Token)
+	require.Equal(uast.Position{Line: 1, Col: 17}, *child(ann, "value").StartPosition)
+
+	// def f(self, a, *b):  # type: (int, *str) -> Optional[bool]
+	f := n.Children[1]
+	require.Equal("FunctionDef", f.InternalType)
+	returns := child(f, "returns")
+	require.NotNil(returns)
+	require.Contains(returns.Roles, uast.Annotation)
+	require.Equal("Subscript", returns.InternalType)
+
+	args := arguments(child(f, "args"))
+	require.Len(args, 3)
+	require.Nil(child(args[0], "annotation"))
+	require.Equal("int", child(args[1], "annotation").Token)
+	require.Equal("str", child(args[2], "annotation").Token)
+	require.Contains(child(args[2], "annotation").Roles, uast.Annotation)
+}
+
+const typeCommentsPy2Code = `def f(a, b, *c, **d):  # type: (int, str, *bool, **float) -> None
+    pass
+`
+
+// typeCommentsPy2Native is the native AST of typeCommentsPy2Code, whose
+// parameters are names and whose variable arguments are properties.
+const typeCommentsPy2Native = `{"PY2AST": {
+  "ast_type": "Module",
+  "body": [{
+    "ast_type": "FunctionDef", "name": "f", "lineno": 1, "col_offset": 5,
+    "args": {"ast_type": "arguments",
+      "args": [
+        {"ast_type": "Name", "id": "a", "ctx": "Param", "lineno": 1, "col_offset": 7},
+        {"ast_type": "Name", "id": "b", "ctx": "Param", "lineno": 1, "col_offset": 10}
+      ],
+      "vararg": "c", "kwarg": "d", "defaults": []},
+    "body": [{"ast_type": "Pass", "lineno": 2, "col_offset": 5}],
+    "decorator_list": [],
+    "noops_sameline": {"ast_type": "SameLineNoops",
+      "noop_line": ["# type: (int, str, *bool, **float) -> None"],
+      "lineno": 1, "col_offset": 24, "end_lineno": 1, "end_col_offset": 65}
+  }]
+}}`
+
+func TestTypeCommentParserPy2(t *testing.T) {
+	require := require.New(t)
+
+	var obj map[string]interface{}
+	require.NoError(json.Unmarshal([]byte(typeCommentsPy2Native), &obj))
+
+	n, err := ToNode.ToNode(obj)
+	require.NoError(err)
+
+	for _, tr := range TransformersFor(ModeDefault) {
+		require.NoError(tr.Do(typeCommentsPy2Code, protocol.UTF8, n))
+	}
+
+	f := n.Children[0]
+	require.Equal("NoneLiteral", child(f, "returns").InternalType)
+
+	args := child(f, "args")
+	params := arguments(args)
+	require.Len(params, 2)
+	require.Equal("Name", params[0].InternalType)
+	require.Equal("int", child(params[0], "annotation").Token)
+	require.Equal("str", child(params[1], "annotation").Token)
+
+	vararg := child(args, "varargannotation")
+	require.Equal("bool", vararg.Token)
+	require.Equal("true", vararg.Properties[TypeCommentKey])
+	require.Contains(vararg.Roles, uast.Annotation)
+	require.Equal("float", child(args, "kwargannotation").Token)
+
+	// the declared types
+	require.Equal("int", params[0].Properties[pytypes.TypeKey])
+	require.Equal("str", params[1].Properties[pytypes.TypeKey])
+	require.Equal("bool", args.Properties[pytypes.VarargTypeKey])
+	require.Equal("float", args.Properties[pytypes.KwargTypeKey])
+	require.Equal("None", f.Properties[pytypes.ReturnTypeKey])
+}
+
+func TestTypeCommentParserPerArgument(t *testing.T) {
+	require := require.New(t)
+
+	code := "def f(a,  # type: int\n      b,  # type: str\n      ):\n    # type: (...) -> None\n    pass\n"
+	comment := func(l uint32, text string) *uast.Node {
+		return &uast.Node{
+			InternalType:  "SameLineNoops",
+			Properties:    map[string]string{},
+			Token:         "[" + text + "]",
+			StartPosition: &uast.Position{Line: l},
+		}
+	}
+
+	arg := func(l uint32, name string) *uast.Node {
+		return &uast.Node{
+			InternalType:  "arg",
+			Properties:    map[string]string{uast.InternalRoleKey: "args"},
+			Token:         name,
+			StartPosition: &uast.Position{Line: l},
+		}
+	}
+
+	a, b := arg(1, "a"), arg(2, "b")
+	f := &uast.Node{
+		InternalType:  "FunctionDef",
+		Properties:    map[string]string{},
+		StartPosition: &uast.Position{Line: 1},
+		Children: []*uast.Node{{
+			InternalType: "arguments",
+			Properties:   map[string]string{uast.InternalRoleKey: "args"},
+			Children:     []*uast.Node{a, b},
+		}, {
+			InternalType: "FunctionDef.body",
+			Properties:   map[string]string{},
+			Children: []*uast.Node{{
+				InternalType:  "Pass",
+				Properties:    map[string]string{},
+				StartPosition: &uast.Position{Line: 5},
+				Children:      []*uast.Node{noopLine(4, " type: (...) -> None\n")},
+			}},
+		}, comment(1, "# type: int"), comment(2, "# type: str")},
+	}
+
+	module := &uast.Node{InternalType: "Module", Children: []*uast.Node{f}}
+	require.NoError(NewTypeCommentParser().Do(code, protocol.UTF8, module))
+
+	require.Equal("int", child(a, "annotation").Token)
+	require.Equal("str", child(b, "annotation").Token)
+	require.Equal("NoneLiteral", child(f, "returns").InternalType)
+}
+
+func TestTypeCommentParserIgnore(t *testing.T) {
+	require := require.New(t)
+
+	code := "x = f()  # type: ignore\n"
+	assign := &uast.Node{
+		InternalType:  "Assign",
+		Properties:    map[string]string{},
+		StartPosition: &uast.Position{Line: 1},
+		Children: []*uast.Node{{
+			InternalType:  "SameLineNoops",
+			Properties:    map[string]string{},
+			Token:         "[# type: ignore]",
+			StartPosition: &uast.Position{Line: 1},
+		}},
+	}
+
+	module := &uast.Node{InternalType: "Module", Children: []*uast.Node{assign}}
+	require.NoError(NewTypeCommentParser().Do(code, protocol.UTF8, module))
+	require.Nil(child(assign, "annotation"))
+}
+
+func TestTypeParser(t *testing.T) {
+	require := require.New(t)
+
+	p := newTypeParser("Dict[str, typing.List['A']]  # comment", 3, 10)
+	obj, err := p.Expr()
+	require.NoError(err)
+	require.True(p.done())
+
+	n := typeNode(obj)
+	require.NotNil(n)
+	require.Equal("Subscript", n.InternalType)
+	require.Equal(uast.Position{Line: 3, Col: 10}, *n.StartPosition)
+
+	value := child(n, "value")
+	require.Equal("Dict", value.Token)
+	require.Equal(uast.Position{Line: 3, Col: 13}, *value.EndPosition)
+
+	tuple := child(child(n, "slice"), "value")
+	require.Equal("Tuple", tuple.InternalType)
+	require.Len(tuple.Children, 2)
+	require.Equal("Attribute", child(tuple.Children[1], "value").InternalType)
+
+	for _, text := range []string{"List[int", "(int) -> ", "int]"} {
+		p := newTypeParser(text, 1, 1)
+		_, err := p.Expr()
+		require.True(err != nil || !p.done(), text)
+	}
+}
+
+func TestTypeParserUnion(t *testing.T) {
+	require := require.New(t)
+
+	p := newTypeParser("int | str | None", 1, 1)
+	obj, err := p.Expr()
+	require.NoError(err)
+	require.True(p.done())
+
+	n := typeNode(obj)
+	require.Equal("BinOp", n.InternalType)
+	require.Equal("NoneLiteral", child(n, "right").InternalType)
+	require.Equal("|", child(n, "op").Token)
+	require.Equal("BinOp", child(n, "left").InternalType)
+}
@@ -0,0 +1,347 @@
+package normalizer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// typeParser parses the type expressions found in PEP 484 type comments into
+// objects with the same shape as the native AST, so they can be converted by
+// ToNode like any other node.
+type typeParser struct {
+	text string
+	pos  int
+	// line and col are the position of the start of text in the file.
+	line, col int
+	// tok is the current token, starting at start.
+	tok   string
+	start int
+}
+
+func newTypeParser(text string, line, col int) *typeParser {
+	p := &typeParser{text: text, line: line, col: col}
+	p.next()
+	return p
+}
+
+// done returns true if there's nothing but a comment left to parse.
+func (p *typeParser) done() bool {
+	return p.tok == "" || strings.HasPrefix(p.tok, "#")
+}
+
+func (p *typeParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid type at column %d: %s", p.col+p.start,
+		fmt.Sprintf(format, args...))
+}
+
+// next moves to the next token, returning the current one.
+func (p *typeParser) next() string {
+	tok := p.tok
+	for p.pos < len(p.text) && strings.IndexByte(" \t\f\r\n", p.text[p.pos]) >= 0 {
+		p.pos++
+	}
+
+	p.start = p.pos
+	if p.pos == len(p.text) {
+		p.tok = ""
+		return tok
+	}
+
+	rest := p.text[p.pos:]
+	r, size := utf8.DecodeRuneInString(rest)
+	switch {
+	case isIdentifier(r, false):
+		end := strings.IndexFunc(rest, func(r rune) bool { return !isIdentifier(r, true) })
+		if end < 0 {
+			end = len(rest)
+		}
+
+		size = end
+	case unicode.IsDigit(r):
+		end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+		if end < 0 {
+			end = len(rest)
+		}
+
+		size = end
+	case r == '\'' || r == '"':
+		end := strings.IndexRune(rest[1:], r)
+		if end < 0 {
+			size = len(rest)
+		} else {
+			size = end + 2
+		}
+	case strings.HasPrefix(rest, "..."):
+		size = 3
+	case strings.HasPrefix(rest, "**"), strings.HasPrefix(rest, "->"):
+		size = 2
+	case r == '#':
+		size = len(rest)
+	}
+
+	p.tok = rest[:size]
+	p.pos += size
+	return tok
+}
+
+func isIdentifier(r rune, inner bool) bool {
+	return r == '_' || unicode.IsLetter(r) || (inner && unicode.IsDigit(r))
+}
+
+func (p *typeParser) expect(tok string) error {
+	if p.tok != tok {
+		return p.errorf("expected %q, found %q", tok, p.tok)
+	}
+
+	p.next()
+	return nil
+}
+
+// node returns a new native node of the given type spanning from start to end
+// (the offsets in text); the end position is omitted if end is not after start.
+func (p *typeParser) node(typ string, start, end int) map[string]interface{} {
+	n := map[string]interface{}{
+		"ast_type":   typ,
+		"lineno":     float64(p.line),
+		"col_offset": float64(p.col + start),
+	}
+
+	if end > start {
+		n["end_lineno"] = float64(p.line)
+		n["end_col_offset"] = float64(p.col + end - 1)
+	}
+
+	return n
+}
+
+// Expr parses a type expression.
+func (p *typeParser) Expr() (map[string]interface{}, error) {
+	begin := p.start
+	n, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	// PEP 604 unions: int | None
+	for p.tok == "|" {
+		p.next()
+		right, err := p.primary()
+		if err != nil {
+			return nil, err
+		}
+
+		op := map[string]interface{}{"ast_type": "BitOr"}
+		or := p.node("BinOp", begin, begin)
+		or["left"], or["op"], or["right"] = n, op, right
+		n = or
+	}
+
+	return n, nil
+}
+
+func (p *typeParser) primary() (map[string]interface{}, error) {
+	begin := p.start
+	n, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.tok {
+		case ".":
+			p.next()
+			start, name := p.start, p.tok
+			r, _ := utf8.DecodeRuneInString(name)
+			if !isIdentifier(r, false) {
+				return nil, p.errorf("expected a name, found %q", name)
+			}
+
+			p.next()
+			attr := p.node("Attribute", start, start+len(name))
+			attr["attr"] = name
+			attr["ctx"] = "Load"
+			attr["value"] = n
+			n = attr
+		case "[":
+			start := p.start
+			p.next()
+			elts, tuple, err := p.list("]")
+			if err != nil {
+				return nil, err
+			}
+
+			var value map[string]interface{}
+			if len(elts) == 1 && !tuple {
+				value = elts[0].(map[string]interface{})
+			} else {
+				value = p.node("Tuple", start+1, start+1)
+				value["ctx"] = "Load"
+				value["elts"] = elts
+			}
+
+			sub := p.node("Subscript", begin, begin)
+			sub["ctx"] = "Load"
+			sub["value"] = n
+			sub["slice"] = map[string]interface{}{"ast_type": "Index", "value": value}
+			n = sub
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *typeParser) atom() (map[string]interface{}, error) {
+	start, tok := p.start, p.tok
+	end := start + len(tok)
+	r, _ := utf8.DecodeRuneInString(tok)
+	switch {
+	case tok == "None":
+		p.next()
+		n := p.node("NoneLiteral", start, end)
+		n["LiteralValue"] = "None"
+		return n, nil
+	case tok == "True" || tok == "False":
+		p.next()
+		n := p.node("BoolLiteral", start, end)
+		n["LiteralValue"] = tok
+		return n, nil
+	case tok == "...":
+		p.next()
+		return p.node("Ellipsis", start, start), nil
+	case tok == "[" || tok == "(":
+		p.next()
+		closing := "]"
+		if tok == "(" {
+			closing = ")"
+		}
+
+		elts, tuple, err := p.list(closing)
+		if err != nil {
+			return nil, err
+		}
+
+		if tok == "(" && len(elts) == 1 && !tuple {
+			return elts[0].(map[string]interface{}), nil
+		}
+
+		typ := "List"
+		if tok == "(" {
+			typ = "Tuple"
+		}
+
+		n := p.node(typ, start, start)
+		n["ctx"] = "Load"
+		n["elts"] = elts
+		return n, nil
+	case r == '\'' || r == '"':
+		if len(tok) < 2 || tok[len(tok)-1] != tok[0] {
+			return nil, p.errorf("unterminated string")
+		}
+
+		p.next()
+		n := p.node("Str", start, end)
+		n["s"] = tok[1 : len(tok)-1]
+		return n, nil
+	case unicode.IsDigit(r):
+		v, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %q", tok)
+		}
+
+		p.next()
+		n := p.node("Num", start, end)
+		n["n"] = v
+		return n, nil
+	case isIdentifier(r, false):
+		p.next()
+		n := p.node("Name", start, end)
+		n["id"] = tok
+		n["ctx"] = "Load"
+		return n, nil
+	}
+
+	return nil, p.errorf("unexpected %q", tok)
+}
+
+// list parses a comma separated list of expressions up to closing, returning
+// whether it has a trailing comma (so it's a tuple even with one element).
+func (p *typeParser) list(closing string) ([]interface{}, bool, error) {
+	var (
+		elts  []interface{}
+		comma bool
+	)
+
+	for p.tok != closing {
+		n, err := p.Expr()
+		if err != nil {
+			return nil, false, err
+		}
+
+		elts = append(elts, n)
+		comma = p.tok == ","
+		if !comma {
+			break
+		}
+
+		p.next()
+	}
+
+	if err := p.expect(closing); err != nil {
+		return nil, false, err
+	}
+
+	return elts, comma || len(elts) > 1, nil
+}
+
+// signature is a function type comment: "(int, *str) -> bool". The args are
+// nil if the arguments are given as "(...)".
+type signature struct {
+	args    []map[string]interface{}
+	returns map[string]interface{}
+}
+
+// Signature parses a function type comment.
+func (p *typeParser) Signature() (*signature, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	s := &signature{}
+	if p.tok == "..." {
+		p.next()
+	} else {
+		s.args = []map[string]interface{}{}
+		for p.tok != ")" {
+			if p.tok == "*" || p.tok == "**" {
+				p.next()
+			}
+
+			n, err := p.Expr()
+			if err != nil {
+				return nil, err
+			}
+
+			s.args = append(s.args, n)
+			if p.tok != "," {
+				break
+			}
+
+			p.next()
+		}
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("->"); err != nil {
+		return nil, err
+	}
+
+	var err error
+	s.returns, err = p.Expr()
+	return s, err
+}
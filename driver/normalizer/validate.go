@@ -0,0 +1,441 @@
+package normalizer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast"
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast/asdl"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidNativeAST is returned when a native AST doesn't follow the
+// pyast.Schema.
+var ErrInvalidNativeAST = errors.NewKind("invalid native AST")
+
+// ViolationKind is the kind of a Violation.
+type ViolationKind int
+
+const (
+	// InvalidValue is a value of the wrong type, as a list for a single node.
+	InvalidValue ViolationKind = iota
+	// MissingType is an object without ast_type.
+	MissingType
+	// UnknownType is a node type not in the grammar of the Python version.
+	UnknownType
+	// UnexpectedType is a node of a type not allowed in the field.
+	UnexpectedType
+	// MissingField is a required field not set.
+	MissingField
+	// UnknownField is a field not in the grammar of the Python version.
+	UnknownField
+)
+
+var violationKindNames = map[ViolationKind]string{
+	InvalidValue:   "invalid value",
+	MissingType:    "missing type",
+	UnknownType:    "unknown type",
+	UnexpectedType: "unexpected type",
+	MissingField:   "missing field",
+	UnknownField:   "unknown field",
+}
+
+// String returns the name of the ViolationKind.
+func (k ViolationKind) String() string {
+	return violationKindNames[k]
+}
+
+// Violation is a difference between a native AST and the schema.
+type Violation struct {
+	Kind ViolationKind
+	// Path is the JSON path of the value, as $.PY3AST.body[0].args.
+	Path    string
+	Message string
+}
+
+// String returns the path, kind and message of the violation.
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s: %s: %s", v.Path, v.Kind, v.Message)
+}
+
+// Violations are the violations found by a Validator, it's an error too.
+type Violations []*Violation
+
+// Error implements error.
+func (vs Violations) Error() string {
+	var msgs []string
+	for _, v := range vs {
+		msgs = append(msgs, v.String())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// ValidationMode sets which violations a Validator reports.
+type ValidationMode int
+
+const (
+	// Lenient allows the node types and fields not in the grammar, which are
+	// left unchecked, as the ones of newer Python versions.
+	Lenient ValidationMode = iota
+	// Strict reports any difference with the grammar.
+	Strict
+)
+
+// rootVersions are the Python versions of the native ASTs, by their root key.
+var rootVersions = map[string]string{
+	"PY2AST": "2.7",
+	"PY3AST": "3.6",
+}
+
+// attributes are the position fields of the nodes.
+var attributes = map[string]bool{
+	"lineno":         true,
+	"col_offset":     true,
+	"end_lineno":     true,
+	"end_col_offset": true,
+}
+
+// nativeTypes are the node types added by the native driver, with the sum
+// type they replace. Their fields are not checked.
+var nativeTypes = map[string]string{
+	"BoolLiteral":    "expr",
+	"NoneLiteral":    "expr",
+	"StringLiteral":  "expr",
+	"NumLiteral":     "expr",
+	"ByteLiteral":    "expr",
+	"PreviousNoops":  "",
+	"SameLineNoops":  "",
+	"RemainderNoops": "",
+	"NoopLine":       "",
+	// added by the parser in ModeRecover
+	Error: "stmt",
+}
+
+// nativeFields are the fields added by the native driver to any node.
+var nativeFields = map[string]bool{
+	"noops_previous":  true,
+	"noops_sameline":  true,
+	"noops_remainder": true,
+	"LiteralValue":    true,
+}
+
+// nullableElements are the list fields that may have null elements, as the
+// key of **d in a dict.
+var nullableElements = map[string]bool{
+	"Dict.keys":             true,
+	"arguments.kw_defaults": true,
+}
+
+// nameElements are the identifier list fields whose elements are converted to
+// Name nodes (without ctx) by the native driver.
+var nameElements = map[string]bool{
+	"Global.names":   true,
+	"Nonlocal.names": true,
+}
+
+// Validator checks the native ASTs against the pyast.Schema.
+type Validator struct {
+	Mode ValidationMode
+	// Version is the Python version of the grammar, as "3.6". If empty, it's
+	// taken from the root key of the AST (PY2AST or PY3AST) or any version
+	// is accepted.
+	Version string
+}
+
+// NewValidator returns a new Validator.
+func NewValidator(mode ValidationMode, version string) *Validator {
+	return &Validator{Mode: mode, Version: version}
+}
+
+// Validate returns the violations found in a native AST: either its root
+// object, with the PY2AST or PY3AST key, or a node.
+func (v *Validator) Validate(ast map[string]interface{}) Violations {
+	c := &validation{Validator: v, version: v.Version}
+	if _, ok := ast[ToNode.InternalTypeKey]; ok {
+		c.object("$", ast, "")
+		return c.violations
+	}
+
+	keys := make([]string, 0, len(ast))
+	for k := range ast {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	for _, k := range keys {
+		c.version = v.Version
+		if c.version == "" {
+			c.version = rootVersions[k]
+		}
+
+		c.value("$."+k, ast[k], "mod", asdl.One, "")
+	}
+
+	return c.violations
+}
+
+// OnToNode validates the native AST, to be set as the OnToNode of a
+// `uast.ObjectToNode`. It fails with ErrInvalidNativeAST if there are
+// violations.
+func (v *Validator) OnToNode(ast interface{}) (interface{}, error) {
+	m, ok := ast.(map[string]interface{})
+	if !ok {
+		return ast, nil
+	}
+
+	if vs := v.Validate(m); len(vs) > 0 {
+		return nil, ErrInvalidNativeAST.Wrap(vs)
+	}
+
+	return ast, nil
+}
+
+type validation struct {
+	*Validator
+	version    string
+	violations Violations
+}
+
+func (c *validation) report(kind ViolationKind, path, format string, args ...interface{}) {
+	c.violations = append(c.violations, &Violation{
+		Kind:    kind,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// exists returns true if the version range includes the version validated.
+func (c *validation) exists(r pyast.VersionRange) bool {
+	return c.version == "" || r.Contains(c.version)
+}
+
+// value checks the value of a field of type typ, named by its parent and
+// field names (as "Dict.keys").
+func (c *validation) value(path string, val interface{}, typ string, card asdl.Cardinality, field string) {
+	if card == asdl.Sequence {
+		list, ok := val.([]interface{})
+		if !ok {
+			c.report(InvalidValue, path, "expected a list of %s, found %s", typ, describe(val))
+			return
+		}
+
+		for i, e := range list {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case e == nil && nullableElements[field]:
+			case nameElements[field] && describe(e) == "Name":
+				// without ctx, so not an expr
+			default:
+				c.value(p, e, typ, asdl.One, field)
+			}
+		}
+
+		return
+	}
+
+	if val == nil {
+		if card != asdl.Optional && typ != "constant" && typ != "singleton" {
+			c.report(MissingField, path, "expected %s, found null", typ)
+		}
+
+		return
+	}
+
+	var ok bool
+	switch typ {
+	case "identifier", "string", "bytes":
+		_, ok = val.(string)
+	case "int":
+		f, isNum := val.(float64)
+		ok = isNum && f == math.Trunc(f)
+	case "bool", "singleton":
+		_, ok = val.(bool)
+	case "object", "constant":
+		ok = true
+	default:
+		c.node(path, val, typ)
+		return
+	}
+
+	if !ok {
+		c.report(InvalidValue, path, "expected %s, found %s", typ, describe(val))
+	}
+}
+
+// node checks a value of a node type: an object or, for the constructors
+// without fields as expr_context, its name.
+func (c *validation) node(path string, val interface{}, typ string) {
+	switch v := val.(type) {
+	case string:
+		if n := pyast.Schema[v]; n != nil && len(n.Fields) == 0 && belongs(n, typ) {
+			return
+		}
+
+		c.report(UnexpectedType, path, "expected %s, found %q", typ, v)
+	case map[string]interface{}:
+		c.object(path, v, typ)
+	default:
+		c.report(InvalidValue, path, "expected %s, found %s", typ, describe(val))
+	}
+}
+
+// object checks a native node, which must be of type typ (if not empty).
+func (c *validation) object(path string, obj map[string]interface{}, typ string) {
+	name, ok := obj[ToNode.InternalTypeKey].(string)
+	if !ok {
+		c.report(MissingType, path, "object without %s", ToNode.InternalTypeKey)
+		return
+	}
+
+	if native, ok := nativeTypes[name]; ok {
+		if typ != "" && native != typ {
+			c.report(UnexpectedType, path, "expected %s, found %s", typ, name)
+		}
+
+		return
+	}
+
+	n := pyast.Schema[name]
+	if n == nil || n.Abstract || !c.exists(n.VersionRange) {
+		if c.Mode == Strict {
+			c.report(UnknownType, path, "%s is not a node type of Python %s", name, c.versionName())
+		}
+
+		return
+	}
+
+	if typ != "" && !belongs(n, typ) {
+		c.report(UnexpectedType, path, "expected %s, found %s", typ, name)
+		return
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == ToNode.InternalTypeKey || nativeFields[k] {
+			continue
+		}
+
+		p := path + "." + k
+		if attributes[k] {
+			if _, ok := obj[k].(float64); !ok && obj[k] != nil {
+				c.report(InvalidValue, p, "expected int, found %s", describe(obj[k]))
+			}
+
+			continue
+		}
+
+		fields := c.fields(n, k)
+		if len(fields) == 0 {
+			if c.Mode == Strict {
+				c.report(UnknownField, p, "%s has no field %s in Python %s", name, k, c.versionName())
+			}
+
+			continue
+		}
+
+		// the value must match one of the versions of the field
+		var found Violations
+		for _, f := range fields {
+			saved := c.violations
+			c.violations = nil
+			c.value(p, obj[k], f.Type, f.Cardinality, name+"."+k)
+			vs := c.violations
+			c.violations = saved
+			if len(vs) == 0 {
+				found = nil
+				break
+			}
+
+			if found == nil {
+				found = vs
+			}
+		}
+
+		c.violations = append(c.violations, found...)
+	}
+
+	for _, f := range n.Fields {
+		if _, ok := obj[f.Name]; ok || !c.required(n, f) {
+			continue
+		}
+
+		c.report(MissingField, path+"."+f.Name, "missing %s%s %s of %s", f.Type, f.Cardinality, f.Name, name)
+	}
+}
+
+// fields returns the versions of a field of a node in the version validated.
+func (c *validation) fields(n *pyast.NodeSchema, name string) []*pyast.FieldSchema {
+	var fields []*pyast.FieldSchema
+	for _, f := range n.Fields {
+		if f.Name == name && c.exists(f.VersionRange) {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// required returns true if a field must be set in the version validated or,
+// without version, in all the versions of the node.
+func (c *validation) required(n *pyast.NodeSchema, f *pyast.FieldSchema) bool {
+	if f.Cardinality != asdl.One || !c.exists(f.VersionRange) {
+		return false
+	}
+
+	return c.version != "" || f.VersionRange == n.VersionRange
+}
+
+func (c *validation) versionName() string {
+	if c.version == "" {
+		return "2 or 3"
+	}
+
+	return c.version
+}
+
+// belongs returns true if a node is of type typ: a constructor of the sum type
+// or the product type itself.
+func belongs(n *pyast.NodeSchema, typ string) bool {
+	if n.Name == typ {
+		return true
+	}
+
+	for _, t := range n.Types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+func describe(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case float64:
+		return fmt.Sprintf("number %v", v)
+	case bool:
+		return fmt.Sprintf("bool %v", v)
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		if t, ok := v[ToNode.InternalTypeKey].(string); ok {
+			return t
+		}
+
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", val)
+}
@@ -0,0 +1,124 @@
+package normalizer
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func nativeAST(t *testing.T, src string) map[string]interface{} {
+	var obj map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(src), &obj))
+	return obj
+}
+
+func TestValidateFixtures(t *testing.T) {
+	require := require.New(t)
+
+	paths, err := filepath.Glob(filepath.Join(driverFixtureDir, "*.native"))
+	require.NoError(err)
+	require.NotEmpty(paths)
+
+	for _, mode := range []ValidationMode{Lenient, Strict} {
+		v := NewValidator(mode, "")
+		for _, path := range paths {
+			native, err := ioutil.ReadFile(path)
+			require.NoError(err)
+
+			var resp struct {
+				AST map[string]interface{} `json:"ast"`
+			}
+
+			require.NoError(json.Unmarshal(native, &resp))
+			require.Empty(v.Validate(resp.AST), "%s", path)
+		}
+	}
+}
+
+// invalidNative has a missing field, a value of the wrong type, an unknown
+// node type and an unknown field.
+const invalidNative = `{"PY3AST": {
+  "ast_type": "Module",
+  "body": [
+    {"ast_type": "Assign", "lineno": 1, "col_offset": 1,
+      "targets": [{"ast_type": "Name", "id": "x", "ctx": "Store"}]},
+    {"ast_type": "Expr", "lineno": 2, "col_offset": 1,
+      "value": {"ast_type": "Name", "id": 1, "ctx": "Load", "kind": "x"}},
+    {"ast_type": "Print", "lineno": 3, "col_offset": 1, "nl": true},
+    {"ast_type": "Pass", "lineno": "4", "col_offset": 1}
+  ]
+}}`
+
+func TestValidateStrict(t *testing.T) {
+	require := require.New(t)
+
+	vs := NewValidator(Strict, "").Validate(nativeAST(t, invalidNative))
+	require.Equal(Violations{
+		{MissingField, "$.PY3AST.body[0].value", "missing expr value of Assign"},
+		{InvalidValue, "$.PY3AST.body[1].value.id", "expected identifier, found number 1"},
+		{UnknownField, "$.PY3AST.body[1].value.kind", "Name has no field kind in Python 3.6"},
+		{UnknownType, "$.PY3AST.body[2]", "Print is not a node type of Python 3.6"},
+		{InvalidValue, "$.PY3AST.body[3].lineno", `expected int, found string "4"`},
+	}, vs)
+}
+
+func TestValidateLenient(t *testing.T) {
+	require := require.New(t)
+
+	vs := NewValidator(Lenient, "").Validate(nativeAST(t, invalidNative))
+	require.Equal(Violations{
+		{MissingField, "$.PY3AST.body[0].value", "missing expr value of Assign"},
+		{InvalidValue, "$.PY3AST.body[1].value.id", "expected identifier, found number 1"},
+		{InvalidValue, "$.PY3AST.body[3].lineno", `expected int, found string "4"`},
+	}, vs)
+}
+
+func TestValidateVersion(t *testing.T) {
+	require := require.New(t)
+
+	print := `{"ast_type": "Print", "values": [], "nl": true}`
+	require.Empty(NewValidator(Strict, "2.7").Validate(nativeAST(t, print)))
+	require.Empty(NewValidator(Strict, "").Validate(nativeAST(t, print)))
+
+	vs := NewValidator(Strict, "3.6").Validate(nativeAST(t, print))
+	require.Len(vs, 1)
+	require.Equal(UnknownType, vs[0].Kind)
+	require.Equal("$", vs[0].Path)
+}
+
+func TestValidateUnexpectedType(t *testing.T) {
+	require := require.New(t)
+
+	ast := nativeAST(t, `{"PY3AST": {"ast_type": "Module", "body": [
+	  {"ast_type": "Expr", "value": {"ast_type": "Pass"}},
+	  {"ast_type": "Expr", "value": {"ast_type": "Name", "id": "x", "ctx": "Add"}},
+	  {"ast_type": "Expr", "value": {"ast_type": "Num", "n": 1}}
+	]}}`)
+
+	require.Equal(Violations{
+		{UnexpectedType, "$.PY3AST.body[0].value", "expected expr, found Pass"},
+		{UnexpectedType, "$.PY3AST.body[1].value.ctx", `expected expr_context, found "Add"`},
+	}, NewValidator(Strict, "").Validate(ast))
+}
+
+func TestToNodeFor(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(ToNode, ToNodeFor(ModeDefault))
+
+	_, err := ToNodeFor(ModeValidate).ToNode(nativeAST(t, invalidNative))
+	require.True(ErrInvalidNativeAST.Is(err))
+	require.Equal("invalid native AST: "+NewValidator(Lenient, "").Validate(nativeAST(t, invalidNative)).Error(), err.Error())
+
+	m, err := ParseMode("validate-strict")
+	require.NoError(err)
+	_, err = ToNodeFor(m).ToNode(nativeAST(t, `{"PY3AST": {"ast_type": "Module",
+	  "body": [{"ast_type": "Pass", "lineno": 1, "col_offset": 1, "kind": 1}]}}`))
+	require.True(ErrInvalidNativeAST.Is(err))
+
+	_, err = ToNodeFor(ModeValidate).ToNode(nativeAST(t, forwardRefsNative))
+	require.NoError(err)
+}
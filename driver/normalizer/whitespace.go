@@ -0,0 +1,90 @@
+package normalizer
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// BlankLinesKey is the property holding the number of blank lines removed from
+// a node by the WhitespaceRemover.
+const BlankLinesKey = "blankLines"
+
+// WhitespaceRemover is a `transformer.Tranformer` that removes the whitespace
+// only lines from the PreviousNoops and RemainderNoops nodes, keeping the
+// comments (and their positions) untouched. The number of lines removed is
+// stored in the BlankLinesKey property of their parent; if no lines are left
+// the noop container is removed too and the count goes to its own parent.
+type WhitespaceRemover struct{}
+
+// NewWhitespaceRemover returns a new WhitespaceRemover.
+func NewWhitespaceRemover() *WhitespaceRemover {
+	return &WhitespaceRemover{}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *WhitespaceRemover) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	b := &binder{code: code, idx: newLineIndex([]byte(code))}
+	b.removeWhitespace(n)
+	return nil
+}
+
+func (b *binder) removeWhitespace(n *uast.Node) {
+	children := n.Children[:0]
+	removed := 0
+	for _, c := range n.Children {
+		switch c.InternalType {
+		case "PreviousNoops", "RemainderNoops":
+			lines := c.Children[:0]
+			count := 0
+			for _, l := range c.Children {
+				if b.isWhitespace(l) {
+					count++
+					continue
+				}
+
+				lines = append(lines, l)
+			}
+			c.Children = lines
+
+			if len(lines) == 0 && count > 0 {
+				removed += count
+				continue
+			}
+
+			addBlankLines(c, count)
+		default:
+			b.removeWhitespace(c)
+		}
+
+		children = append(children, c)
+	}
+
+	n.Children = children
+	addBlankLines(n, removed)
+}
+
+// isWhitespace checks the source since the token of a blank line is the same
+// as the one of an empty comment ("#").
+func (b *binder) isWhitespace(n *uast.Node) bool {
+	if n.InternalType != "NoopLine" || line(n) == 0 {
+		return false
+	}
+
+	return strings.TrimSpace(b.idx.Line(b.code, line(n))) == ""
+}
+
+func addBlankLines(n *uast.Node, count int) {
+	if count == 0 {
+		return
+	}
+
+	if n.Properties == nil {
+		n.Properties = make(map[string]string)
+	}
+
+	prev, _ := strconv.Atoi(n.Properties[BlankLinesKey])
+	n.Properties[BlankLinesKey] = strconv.Itoa(prev + count)
+}
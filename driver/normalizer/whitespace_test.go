@@ -0,0 +1,93 @@
+package normalizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func noopLine(l uint32, token string) *uast.Node {
+	return &uast.Node{
+		InternalType:  "NoopLine",
+		Properties:    map[string]string{uast.InternalRoleKey: "lines"},
+		Token:         token,
+		StartPosition: &uast.Position{Line: l, Col: 1},
+	}
+}
+
+func TestWhitespaceRemover(t *testing.T) {
+	require := require.New(t)
+
+	code := "x = 1\n\n# about y\n\ny = 2\n\n\n"
+	comment := noopLine(3, " about y\n")
+	previous := &uast.Node{
+		InternalType: "PreviousNoops",
+		Properties:   map[string]string{uast.InternalRoleKey: "noops_previous"},
+		Children:     []*uast.Node{noopLine(2, "\n"), comment, noopLine(4, "\n")},
+	}
+
+	y := &uast.Node{
+		InternalType:  "Assign",
+		Properties:    map[string]string{},
+		StartPosition: &uast.Position{Line: 5, Col: 1},
+		Children:      []*uast.Node{previous},
+	}
+
+	module := &uast.Node{
+		InternalType: "Module",
+		Properties:   map[string]string{},
+		Children: []*uast.Node{y, {
+			InternalType: "RemainderNoops",
+			Properties:   map[string]string{uast.InternalRoleKey: "noops_remainder"},
+			Children:     []*uast.Node{noopLine(6, "\n"), noopLine(7, "\n")},
+		}},
+	}
+
+	err := NewWhitespaceRemover().Do(code, protocol.UTF8, module)
+	require.NoError(err)
+
+	require.Equal([]*uast.Node{comment}, previous.Children)
+	require.Equal(uast.Position{Line: 3, Col: 1}, *comment.StartPosition)
+	require.Equal("2", previous.Properties[BlankLinesKey])
+
+	// the remainder noops were all blank lines
+	require.Equal([]*uast.Node{y}, module.Children)
+	require.Equal("2", module.Properties[BlankLinesKey])
+}
+
+func TestWhitespaceRemoverEmptyComment(t *testing.T) {
+	require := require.New(t)
+
+	// the token of an empty comment is a line break too
+	code := "#\nx = 1\n"
+	previous := &uast.Node{
+		InternalType: "PreviousNoops",
+		Properties:   map[string]string{},
+		Children:     []*uast.Node{noopLine(1, "\n")},
+	}
+
+	module := &uast.Node{InternalType: "Module", Children: []*uast.Node{previous}}
+	err := NewWhitespaceRemover().Do(code, protocol.UTF8, module)
+	require.NoError(err)
+	require.Len(previous.Children, 1)
+	require.Equal("", previous.Properties[BlankLinesKey])
+}
+
+func TestParseMode(t *testing.T) {
+	require := require.New(t)
+
+	m, err := ParseMode("")
+	require.NoError(err)
+	require.Equal(ModeDefault, m)
+
+	m, err = ParseMode(" drop-whitespace, ")
+	require.NoError(err)
+	require.Equal(ModeDropWhitespace, m)
+	require.Equal("drop-whitespace", m.String())
+	require.Len(TransformersFor(m), len(Transformers)+1)
+
+	_, err = ParseMode("drop-whitespace,foo")
+	require.Error(err)
+}
@@ -0,0 +1,375 @@
+package parser
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/bblfsh/sdk.v1/uast/ann"
+)
+
+// Extensions of the files of a Cache.
+const (
+	nativeExt = ".native"
+	uastExt   = ".uast"
+)
+
+// CacheConfig is the configuration of a Cache.
+type CacheConfig struct {
+	// Dir is the directory of the cache, created if it doesn't exist.
+	Dir string
+	// MaxSize is the maximum size of the files in the cache in bytes, after
+	// which the least recently used are removed. Unbounded if 0.
+	MaxSize int64
+	// Native stores the native ASTs and UAST stores the final UASTs. Both are
+	// stored if none is set.
+	Native, UAST bool
+	// DriverVersion and PythonVersion are the versions of the native driver
+	// and the Python interpreter running it, part of the keys of the cache.
+	// If empty, they're asked to the native driver on the first use of the
+	// cache, so they must be set to use it without a native driver.
+	DriverVersion, PythonVersion string
+}
+
+// Cache is an on-disk store of native ASTs and UASTs, addressed by the
+// SHA-256 of the content plus the versions of the driver and, for the UASTs,
+// normalizer.Version, the Mode and the annotation rules. It's safe for concurrent use, with a
+// single native driver.
+type Cache struct {
+	cfg CacheConfig
+
+	// versions guards the versions of the config missing until they're
+	// asked to the native driver.
+	versions sync.Mutex
+	resolved bool
+
+	m       sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int64
+	// rules are the hashes of the annotation rules already seen.
+	rules map[*ann.Rule]string
+}
+
+type cacheEntry struct {
+	name string
+	size int64
+}
+
+// NewCache opens the cache in the directory of the config, keeping the files
+// already there.
+func NewCache(cfg CacheConfig) (*Cache, error) {
+	if !cfg.Native && !cfg.UAST {
+		cfg.Native, cfg.UAST = true, true
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		cfg:      cfg,
+		resolved: cfg.DriverVersion != "" && cfg.PythonVersion != "",
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		rules:    make(map[*ann.Rule]string),
+	}
+
+	var files []os.FileInfo
+	err := filepath.Walk(cfg.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		ext := filepath.Ext(path)
+		if info.Mode().IsRegular() && (ext == nativeExt || ext == uastExt) {
+			files = append(files, info)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// least recently used last
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().After(files[j].ModTime())
+	})
+
+	for _, f := range files {
+		c.entries[f.Name()] = c.lru.PushBack(&cacheEntry{name: f.Name(), size: f.Size()})
+		c.size += f.Size()
+	}
+
+	c.evict()
+	return c, nil
+}
+
+// resolve asks the versions missing in the config to the native driver of
+// opts, returning false if it fails and the cache can't be used.
+func (c *Cache) resolve(ctx context.Context, opts *Options) bool {
+	c.versions.Lock()
+	defer c.versions.Unlock()
+
+	if c.resolved {
+		return true
+	}
+
+	// the empty content is answered without parsing
+	probe := &Options{Native: opts.Native, Args: opts.Args, Pool: opts.Pool}
+	resp, err := parseResponse(ctx, "", probe, false)
+	if err != nil {
+		return false
+	}
+
+	if c.cfg.DriverVersion == "" {
+		driver := resp.Metadata.Driver
+		c.cfg.DriverVersion = driver[strings.Index(driver, ":")+1:]
+	}
+
+	if c.cfg.PythonVersion == "" {
+		c.cfg.PythonVersion = resp.Metadata.NativeVersion
+	}
+
+	c.resolved = true
+	return true
+}
+
+// Size returns the size of the files in the cache in bytes.
+func (c *Cache) Size() int64 {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.size
+}
+
+// nativeKey returns the name of the file of a native AST, for a Python
+// version or detecting it if 0.
+func (c *Cache) nativeKey(content string, version int) string {
+	if version == 0 {
+		return c.key(nativeExt, content)
+	}
+
+	return c.key(nativeExt, content, strconv.Itoa(version))
+}
+
+// uastVersion is the version of the Go side of the driver in the keys of the
+// UASTs.
+var uastVersion = normalizer.Version
+
+// uastKey returns the name of the file of a UAST.
+func (c *Cache) uastKey(source []byte, opts *Options) string {
+	rules := opts.Rules
+	if rules == nil {
+		rules = normalizer.AnnotationRules
+	}
+
+	return c.key(uastExt, string(source), uastVersion, opts.Mode.String(), c.rulesHash(rules),
+		strconv.Itoa(opts.PythonVersion))
+}
+
+func (c *Cache) key(ext, content string, extra ...string) string {
+	sum := sha256.Sum256([]byte(content))
+	h := sha256.New()
+	parts := append([]string{hex.EncodeToString(sum[:]), c.cfg.DriverVersion,
+		c.cfg.PythonVersion}, extra...)
+	h.Write([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(h.Sum(nil)) + ext
+}
+
+// rulesHash returns the hash of the description of the rules (see
+// `ann.Rule.String`).
+func (c *Cache) rulesHash(r *ann.Rule) string {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	h, ok := c.rules[r]
+	if !ok {
+		sum := sha256.Sum256([]byte(r.String()))
+		h = hex.EncodeToString(sum[:])
+		c.rules[r] = h
+	}
+
+	return h
+}
+
+func (c *Cache) path(name string) string {
+	return filepath.Join(c.cfg.Dir, name[:2], name)
+}
+
+// getNative returns the native AST of a content for a Python version, nil if
+// it's not cached.
+func (c *Cache) getNative(content string, version int) map[string]interface{} {
+	if !c.cfg.Native {
+		return nil
+	}
+
+	data := c.get(c.nativeKey(content, version))
+	if data == nil {
+		return nil
+	}
+
+	var ast map[string]interface{}
+	if err := json.Unmarshal(data, &ast); err != nil {
+		return nil
+	}
+
+	return ast
+}
+
+func (c *Cache) putNative(content string, version int, ast map[string]interface{}) error {
+	if !c.cfg.Native {
+		return nil
+	}
+
+	data, err := json.Marshal(ast)
+	if err != nil {
+		return err
+	}
+
+	return c.put(c.nativeKey(content, version), data)
+}
+
+// getUAST returns the UAST of a source, nil if it's not cached.
+func (c *Cache) getUAST(source []byte, opts *Options) *uast.Node {
+	if !c.cfg.UAST {
+		return nil
+	}
+
+	data := c.get(c.uastKey(source, opts))
+	if data == nil {
+		return nil
+	}
+
+	n := &uast.Node{}
+	if err := n.Unmarshal(data); err != nil {
+		return nil
+	}
+
+	return n
+}
+
+func (c *Cache) putUAST(source []byte, opts *Options, n *uast.Node) error {
+	if !c.cfg.UAST {
+		return nil
+	}
+
+	data, err := n.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return c.put(c.uastKey(source, opts), data)
+}
+
+// get returns the content of a file of the cache, nil if it doesn't exist.
+func (c *Cache) get(name string) []byte {
+	c.m.Lock()
+	e, ok := c.entries[name]
+	if ok {
+		c.lru.MoveToFront(e)
+	}
+
+	c.m.Unlock()
+	if !ok {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(c.path(name))
+	if err != nil {
+		c.remove(name)
+		return nil
+	}
+
+	// the modification time keeps the order of use between runs
+	now := time.Now()
+	_ = os.Chtimes(c.path(name), now, now)
+	return data
+}
+
+// put writes a file of the cache, evicting the least recently used ones if it
+// grows beyond the MaxSize.
+func (c *Cache) put(name string, data []byte) error {
+	path := c.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// written to a temporary file and renamed, so it's never read partially
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	size := int64(len(data))
+	if e, ok := c.entries[name]; ok {
+		entry := e.Value.(*cacheEntry)
+		c.size += size - entry.size
+		entry.size = size
+		c.lru.MoveToFront(e)
+	} else {
+		c.entries[name] = c.lru.PushFront(&cacheEntry{name: name, size: size})
+		c.size += size
+	}
+
+	c.evict()
+	return nil
+}
+
+func (c *Cache) remove(name string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if e, ok := c.entries[name]; ok {
+		c.removeEntry(e)
+	}
+}
+
+// evict removes the least recently used files while the cache is bigger than
+// the MaxSize. It must be called with the lock held.
+func (c *Cache) evict() {
+	for c.cfg.MaxSize > 0 && c.size > c.cfg.MaxSize && c.lru.Len() > 0 {
+		e := c.lru.Back()
+		_ = os.Remove(c.path(e.Value.(*cacheEntry).name))
+		c.removeEntry(e)
+	}
+}
+
+func (c *Cache) removeEntry(e *list.Element) {
+	entry := c.lru.Remove(e).(*cacheEntry)
+	delete(c.entries, entry.name)
+	c.size -= entry.size
+}
@@ -0,0 +1,207 @@
+package parser
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/normalizer/pyast"
+	"github.com/bblfsh/python-driver/driver/parser/fakenative"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/bblfsh/sdk.v1/uast/ann"
+)
+
+// noNative are options without a native driver, only for cached results.
+var noNative = &Options{Native: "./no-such-native"}
+
+func tempCache(t *testing.T, cfg CacheConfig) (*Cache, func()) {
+	dir, err := ioutil.TempDir("", "python-driver-cache")
+	require.NoError(t, err)
+
+	cfg.Dir = dir
+	c, err := NewCache(cfg)
+	require.NoError(t, err)
+	return c, func() { os.RemoveAll(dir) }
+}
+
+func TestCacheUAST(t *testing.T) {
+	require := require.New(t)
+
+	c, clean := tempCache(t, CacheConfig{UAST: true})
+	defer clean()
+
+	src := fixture(t, "issue_server101.py")
+	expected, err := Parse(context.Background(), src, &Options{Native: os.Args[0], Cache: c})
+	require.NoError(err)
+
+	n, err := Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: c})
+	require.NoError(err)
+	require.Equal(expected.String(), n.String())
+
+	// the UASTs depend on the Mode and the rules
+	_, err = Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: c,
+		Mode: normalizer.ModeDropWhitespace})
+	require.True(ErrNativeStart.Is(err))
+
+	_, err = Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: c,
+		Rules: normalizer.Overlay(normalizer.AnnotationRules, ann.On(pyast.Pass).Roles(uast.Noop))})
+	require.True(ErrNativeStart.Is(err))
+
+	// but not the native ASTs, not stored
+	_, err = ParseNative(context.Background(), src, &Options{Native: noNative.Native, Cache: c})
+	require.True(ErrNativeStart.Is(err))
+
+	// and on the version of the conversion
+	defer func(v string) { uastVersion = v }(uastVersion)
+	uastVersion += ".1"
+	_, err = Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: c})
+	require.True(ErrNativeStart.Is(err))
+}
+
+func TestCacheNative(t *testing.T) {
+	require := require.New(t)
+
+	c, clean := tempCache(t, CacheConfig{Native: true})
+	defer clean()
+
+	src := fixture(t, "u2_import_rename.py")
+	expected, err := ParseNative(context.Background(), src, &Options{Native: os.Args[0], Cache: c})
+	require.NoError(err)
+
+	ast, err := ParseNative(context.Background(), src, &Options{Native: noNative.Native, Cache: c})
+	require.NoError(err)
+	require.Equal(expected, ast)
+
+	// the UASTs are converted from the cached native AST
+	_, err = Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: c,
+		Mode: normalizer.ModeDropWhitespace})
+	require.NoError(err)
+}
+
+func TestCacheVersions(t *testing.T) {
+	require := require.New(t)
+
+	c, clean := tempCache(t, CacheConfig{DriverVersion: "1.1", PythonVersion: "3.6.2"})
+	defer clean()
+
+	src := fixture(t, "u2_import_rename.py")
+	_, err := Parse(context.Background(), src, &Options{Native: os.Args[0], Cache: c})
+	require.NoError(err)
+
+	other, err := NewCache(CacheConfig{Dir: c.cfg.Dir, DriverVersion: "1.1", PythonVersion: "3.7.0"})
+	require.NoError(err)
+	require.Equal(c.Size(), other.Size())
+
+	_, err = Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: other})
+	require.True(ErrNativeStart.Is(err))
+
+	_, err = Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: c})
+	require.NoError(err)
+}
+
+func TestCacheNativeVersions(t *testing.T) {
+	require := require.New(t)
+
+	c, clean := tempCache(t, CacheConfig{})
+	defer clean()
+
+	src := fixture(t, "u2_import_rename.py")
+	_, err := Parse(context.Background(), src, &Options{Native: os.Args[0], Cache: c})
+	require.NoError(err)
+	require.Equal("fake", c.cfg.DriverVersion)
+	require.Equal(fakenative.NativeVersion, c.cfg.PythonVersion)
+	size := c.Size()
+
+	// the versions are asked again after reopening it
+	same, err := NewCache(CacheConfig{Dir: c.cfg.Dir})
+	require.NoError(err)
+	_, err = Parse(context.Background(), src, &Options{Native: os.Args[0], Cache: same})
+	require.NoError(err)
+	require.Equal(size, same.Size())
+
+	// a native driver running another Python, a new process with other args
+	require.NoError(os.Setenv(fakenative.NativeVersionEnv, "3.7.0"))
+	defer os.Unsetenv(fakenative.NativeVersionEnv)
+
+	upgraded, err := NewCache(CacheConfig{Dir: c.cfg.Dir})
+	require.NoError(err)
+	_, err = Parse(context.Background(), src, &Options{Native: os.Args[0], Args: []string{"upgraded"}, Cache: upgraded})
+	require.NoError(err)
+	require.Equal("3.7.0", upgraded.cfg.PythonVersion)
+	require.True(upgraded.Size() > size)
+
+	// the cache isn't used without a native driver to ask
+	unknown, err := NewCache(CacheConfig{Dir: c.cfg.Dir})
+	require.NoError(err)
+	_, err = Parse(context.Background(), src, &Options{Native: noNative.Native, Cache: unknown})
+	require.True(ErrNativeStart.Is(err))
+}
+
+func TestCacheEviction(t *testing.T) {
+	require := require.New(t)
+
+	c, clean := tempCache(t, CacheConfig{Native: true, MaxSize: 1000})
+	defer clean()
+
+	for _, content := range []string{"a", "b", "c"} {
+		require.NoError(c.putNative(content, 0, map[string]interface{}{"x": strings.Repeat("x", 300)}))
+	}
+
+	require.NotNil(c.getNative("a", 0))
+	require.NoError(c.putNative("d", 0, map[string]interface{}{"x": strings.Repeat("x", 300)}))
+	require.True(c.Size() <= 1000)
+
+	// b is the least recently used
+	require.Nil(c.getNative("b", 0))
+	_, err := os.Stat(c.path(c.nativeKey("b", 0)))
+	require.True(os.IsNotExist(err))
+
+	for _, content := range []string{"a", "c", "d"} {
+		require.NotNil(c.getNative(content, 0), content)
+	}
+
+	// the files are kept, with the size bound
+	reopened, err := NewCache(CacheConfig{Dir: c.cfg.Dir, Native: true, MaxSize: 700})
+	require.NoError(err)
+	require.Nil(reopened.getNative("a", 0))
+	require.NotNil(reopened.getNative("c", 0))
+	require.NotNil(reopened.getNative("d", 0))
+
+	files, err := filepath.Glob(filepath.Join(c.cfg.Dir, "*", "*"+nativeExt))
+	require.NoError(err)
+	require.Len(files, 2)
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	require := require.New(t)
+
+	c, clean := tempCache(t, CacheConfig{MaxSize: 1 << 20})
+	defer clean()
+
+	p := fakePool(PoolConfig{Size: 2})
+	defer p.Close()
+
+	names := []string{"u2_import_rename.py", "other_statements.py", "issue_server101.py"}
+	var wg sync.WaitGroup
+	errs := make(chan error, 30)
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			_, err := Parse(context.Background(), fixture(t, name), &Options{Pool: p, Cache: c})
+			errs <- err
+		}(names[i%len(names)])
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(err)
+	}
+}
@@ -0,0 +1,298 @@
+// Package fakenative is a native driver written in Go, for the tests of the
+// programs running the native driver. It answers with the native ASTs of the
+// fixtures and can crash, hang or grow on demand.
+//
+// The test binary itself is used as the native driver, calling Main from
+// TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		fakenative.Main(fixturesDir)
+//		os.Exit(m.Run())
+//	}
+//
+// and running os.Args[0] with the Env variable set.
+package fakenative
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/pep263"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/sdk/driver"
+	"gopkg.in/bblfsh/sdk.v1/sdk/jsonlines"
+)
+
+// Env is the environment variable making Main serve the requests.
+const Env = "PYTHON_DRIVER_FAKE_NATIVE"
+
+// Contents of the requests with a special behavior.
+const (
+	// Crash makes the native driver exit at once.
+	Crash = "# fakenative: crash\n"
+	// Hang makes the native driver never answer.
+	Hang = "# fakenative: hang\n"
+	// PID is answered with the process id, as the AST {"pid": pid}.
+	PID = "# fakenative: pid\n"
+	// Grow is followed by a number of MiB to allocate and keep, answered as
+	// PID.
+	Grow = "# fakenative: grow "
+)
+
+// Versions reported in the metadata of the responses, as the native driver
+// does.
+const (
+	// Driver is the name and version of the native driver.
+	Driver = "python23:fake"
+	// NativeVersion is the version of Python, unless NativeVersionEnv is set
+	// for Main.
+	NativeVersion = "3.6.2"
+	// NativeVersionEnv is the environment variable setting the version of
+	// Python reported by Main, to fake an upgrade.
+	NativeVersionEnv = "PYTHON_DRIVER_FAKE_NATIVE_VERSION"
+)
+
+// Native answers the requests with the responses of a fixed set of contents,
+// and with a fatal syntax error to any other content, at the end of its first
+// line for both Python versions, formatted as the native driver does. The
+// contents are valid only for the Python version of their AST, with a
+// confidence of 1 in the dual responses. The empty content is answered with an
+// empty Module.
+type Native struct {
+	// Responses are the raw JSON responses by content. If nil, they're loaded
+	// from the fixtures in Dir on the first request of a content.
+	Responses map[string]json.RawMessage
+	// Dir is the directory of the fixtures.
+	Dir string
+	// NativeVersion is the version of Python reported, NativeVersion if
+	// empty.
+	NativeVersion string
+
+	kept [][]byte
+}
+
+// Load returns the responses with the native ASTs of the fixtures in dir: for
+// each file.py, its file.py.native. The contents are decoded as the Go driver
+// does (see pep263.Decode).
+func Load(dir string) (map[string]json.RawMessage, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.py"))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make(map[string]json.RawMessage)
+	for _, path := range paths {
+		native, err := ioutil.ReadFile(path + ".native")
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := pep263.Decode(src)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, native); err != nil {
+			return nil, err
+		}
+
+		responses[s.Content] = buf.Bytes()
+	}
+
+	return responses, nil
+}
+
+// Serve answers the requests read from r until it's closed.
+func (n *Native) Serve(r io.Reader, w io.Writer) error {
+	dec := jsonlines.NewDecoder(r)
+	enc := jsonlines.NewEncoder(w)
+	for {
+		var req Request
+		if err := dec.Decode(&req); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		resp, err := n.response(&req)
+		if err != nil {
+			return err
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// Request is a request to the native driver, with the options of the
+// python_driver.
+type Request struct {
+	driver.InternalParseRequest
+	PythonVersion string `json:"python_version"`
+	Dual          bool   `json:"dual"`
+}
+
+func (n *Native) response(req *Request) (interface{}, error) {
+	content := req.Content
+	switch {
+	case content == Crash:
+		os.Exit(2)
+	case content == Hang:
+		select {}
+	case strings.HasPrefix(content, Grow):
+		mib, _ := strconv.Atoi(strings.TrimSpace(content[len(Grow):]))
+		b := make([]byte, mib<<20)
+		for i := range b {
+			b[i] = 1
+		}
+
+		n.kept = append(n.kept, b)
+		fallthrough
+	case content == PID:
+		return &driver.InternalParseResponse{
+			Status: driver.Status(protocol.Ok),
+			AST:    map[string]interface{}{"pid": os.Getpid()},
+		}, nil
+	}
+
+	if content == "" {
+		return n.withVersions(map[string]interface{}{
+			"status": "ok",
+			"errors": []string{},
+			"ast": map[string]interface{}{"PY3AST": map[string]interface{}{
+				"ast_type": "Module", "lineno": 1, "col_offset": 1,
+			}},
+		}), nil
+	}
+
+	if n.Responses == nil {
+		var err error
+		if n.Responses, err = Load(n.Dir); err != nil {
+			return nil, err
+		}
+	}
+
+	raw, ok := n.Responses[content]
+	if !ok {
+		return &driver.InternalParseResponse{
+			Status: driver.Status(protocol.Fatal),
+			Errors: []string{syntaxError(content, "2", "3")},
+		}, nil
+	}
+
+	// the numbers are kept as they are in the fixtures
+	var resp map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&resp); err != nil {
+		return nil, err
+	}
+
+	n.withVersions(resp)
+	if req.PythonVersion == "" && !req.Dual {
+		return resp, nil
+	}
+
+	return versionResponse(req, resp)
+}
+
+// withVersions adds the versions to the metadata of a response.
+func (n *Native) withVersions(resp map[string]interface{}) map[string]interface{} {
+	metadata, _ := resp["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+		resp["metadata"] = metadata
+	}
+
+	version := n.NativeVersion
+	if version == "" {
+		version = NativeVersion
+	}
+
+	metadata["driver"] = Driver
+	metadata["native_version"] = version
+	return resp
+}
+
+// versionResponse answers a request for a Python version or both with the
+// response of a fixture, valid only for the version of its AST.
+func versionResponse(req *Request, resp map[string]interface{}) (interface{}, error) {
+	ast, _ := resp["ast"].(map[string]interface{})
+	version, other := "3", "2"
+	if _, ok := ast["PY2AST"]; ok {
+		version, other = "2", "3"
+	}
+
+	if req.PythonVersion != "" && req.PythonVersion != version {
+		return &driver.InternalParseResponse{
+			Status: driver.Status(protocol.Fatal),
+			Errors: []string{syntaxError(req.Content, req.PythonVersion)},
+		}, nil
+	}
+
+	if req.Dual {
+		metadata := resp["metadata"].(map[string]interface{})
+		metadata["confidence"] = map[string]float64{version: 1, other: 0}
+		resp["errors"] = []string{syntaxError(req.Content, other)}
+	}
+
+	return resp, nil
+}
+
+// syntaxError returns the traceback of the native driver for a syntax error
+// at the end of the first line of the content, in the given versions.
+func syntaxError(content string, versions ...string) string {
+	line := strings.SplitN(content, "\n", 2)[0]
+	text := strings.TrimLeft(line, " \t")
+	caret := strings.Repeat(" ", len(text)+3) + "^"
+	exc := fmt.Sprintf("  File \"<unknown>\", line 1\n    %s\n%s\nSyntaxError: invalid syntax\n", text, caret)
+	quoted := strconv.Quote(exc)
+	quoted = "'" + strings.Replace(quoted[1:len(quoted)-1], "'", "\\'", -1) + "'"
+	msg := "Traceback (most recent call last):\n" +
+		"  File \"requestprocessor.py\", line 136, in process_request\n" +
+		"Exception: Errors produced trying to get an AST for "
+	if len(versions) == 1 {
+		msg += "Python " + versions[0] + "\n"
+	} else {
+		msg += "both Python versions\n"
+	}
+
+	for _, v := range versions {
+		msg += "------ Python" + v + " errors:\n[" + quoted + "]\n"
+	}
+
+	return msg
+}
+
+// Main serves the requests on the standard input and output and exits if the
+// Env variable is set, answering with the fixtures in dir. Otherwise it sets
+// the variable, for the native drivers run by the caller.
+func Main(dir string) {
+	if os.Getenv(Env) == "" {
+		os.Setenv(Env, "1")
+		return
+	}
+
+	n := &Native{Dir: dir, NativeVersion: os.Getenv(NativeVersionEnv)}
+	if err := n.Serve(os.Stdin, os.Stdout); err != nil {
+		panic(err)
+	}
+
+	os.Exit(0)
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/sdk/driver"
+	"gopkg.in/bblfsh/sdk.v1/sdk/jsonlines"
+)
+
+// stopTimeout is the time given to a native driver to exit after closing its
+// input, before killing it.
+const stopTimeout = 5 * time.Second
+
+// native is a running native driver, talking the JSON lines protocol over its
+// standard input and output. Its requests must not be concurrent.
+type native struct {
+	cmd    *exec.Cmd
+	enc    jsonlines.Encoder
+	dec    jsonlines.Decoder
+	stdin  io.Closer
+	stdout io.Closer
+	// exited is closed when the process exits.
+	exited chan struct{}
+}
+
+// startNative runs the native driver command.
+func startNative(name string, args ...string) (*native, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	// not cmd.StdoutPipe, closed by cmd.Wait even if there is a response not
+	// read yet
+	stdout, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.Stdout = w
+	err = cmd.Start()
+	w.Close()
+	if err != nil {
+		stdout.Close()
+		return nil, ErrNativeStart.Wrap(err, name)
+	}
+
+	n := &native{
+		cmd:    cmd,
+		enc:    jsonlines.NewEncoder(stdin),
+		dec:    jsonlines.NewDecoder(stdout),
+		stdin:  stdin,
+		stdout: stdout,
+		exited: make(chan struct{}),
+	}
+
+	go func() {
+		_ = cmd.Wait()
+		close(n.exited)
+	}()
+
+	return n, nil
+}
+
+// request is a request to the native driver, with the options of the
+// python_driver on top of the ones of the SDK.
+type request struct {
+	driver.InternalParseRequest
+	// PythonVersion is "2" or "3", or empty to detect it.
+	PythonVersion string `json:"python_version,omitempty"`
+	// Dual requests the ASTs of both versions.
+	Dual bool `json:"dual,omitempty"`
+}
+
+// response is a response of the native driver, with its metadata.
+type response struct {
+	driver.InternalParseResponse
+	Metadata struct {
+		// Confidence is the confidence of the detector on the code being of
+		// each version, "2" and "3", from 0 to 1. Only sent for Dual requests.
+		Confidence map[string]float64 `json:"confidence"`
+		// Driver is the name and version of the native driver, as
+		// "python23:1.1".
+		Driver string `json:"driver"`
+		// NativeVersion is the version of the Python interpreter running the
+		// native driver.
+		NativeVersion string `json:"native_version"`
+	} `json:"metadata"`
+}
+
+// parse sends a request and waits for its response. The process is killed if
+// the context is done before, as the response can't be skipped.
+func (n *native) parse(ctx context.Context, req *request) (*response, error) {
+	done := make(chan error, 1)
+	resp := &response{}
+	go func() {
+		if err := n.enc.Encode(req); err != nil {
+			done <- err
+			return
+		}
+
+		done <- n.dec.Decode(resp)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, ErrNativeFailed.Wrap(err)
+		}
+
+		return resp, nil
+	case <-ctx.Done():
+		n.kill()
+		<-done
+		return nil, ctx.Err()
+	}
+}
+
+// alive returns true if the process didn't exit.
+func (n *native) alive() bool {
+	select {
+	case <-n.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// rss returns the resident set size of the process in bytes, or 0 if it's
+// not available (only Linux is supported).
+func (n *native) rss() int64 {
+	statm, err := ioutil.ReadFile("/proc/" + strconv.Itoa(n.cmd.Process.Pid) + "/statm")
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0
+	}
+
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return pages * int64(os.Getpagesize())
+}
+
+// stop closes the input of the native driver and waits for it to exit,
+// killing it after the stopTimeout.
+func (n *native) stop() {
+	_ = n.stdin.Close()
+	select {
+	case <-n.exited:
+		_ = n.stdout.Close()
+	case <-time.After(stopTimeout):
+		n.kill()
+	}
+}
+
+// kill terminates the native driver at once.
+func (n *native) kill() {
+	_ = n.cmd.Process.Kill()
+	_ = n.stdin.Close()
+	<-n.exited
+	_ = n.stdout.Close()
+}
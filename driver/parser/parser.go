@@ -0,0 +1,321 @@
+// Package parser parses Python code from Go, without the gRPC server: it runs
+// the native driver (python_driver) as a subprocess, talking the same JSON
+// lines protocol, and converts its AST into a UAST with the normalizer.
+package parser
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/pep263"
+
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/sdk/driver"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/bblfsh/sdk.v1/uast/ann"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// DefaultNative is the command of the native driver, installed by the
+// python_driver package.
+const DefaultNative = "python_driver"
+
+var (
+	// ErrNativeStart is returned when the native driver can't be run.
+	ErrNativeStart = errors.NewKind("can't start native driver %s")
+	// ErrNativeFailed is returned when the native driver exits or sends an
+	// invalid response.
+	ErrNativeFailed = errors.NewKind("native driver failed")
+	// ErrParse is returned when the native driver can't parse the code, with
+	// the errors it reported.
+	ErrParse = errors.NewKind("parse error: %s")
+	// ErrSyntax is returned when the code has syntax errors, with the
+	// SyntaxErrors as its cause (see AsSyntaxErrors).
+	ErrSyntax = errors.NewKind("syntax error")
+	// ErrTransform is returned when the UAST can't be transformed.
+	ErrTransform = errors.NewKind("error transforming the UAST")
+	// ErrPythonVersion is returned for a Python version other than 2 or 3.
+	ErrPythonVersion = errors.NewKind("invalid Python version %q: must be 2, 3 or auto")
+)
+
+// Options are the options of Parse and ParseNative.
+type Options struct {
+	// Native is the command of the native driver, DefaultNative if empty.
+	Native string
+	// Args are the arguments of the native driver command.
+	Args []string
+	// Filename is the name of the file reported in the syntax errors.
+	Filename string
+	// PythonVersion is the major version of Python to parse the code with, 2
+	// or 3. The native driver detects it if 0, preferring Python 3 if the
+	// code is valid for both.
+	PythonVersion int
+	// Mode is the Mode of the normalizer (see normalizer.ParseMode).
+	Mode normalizer.Mode
+	// Rules are the annotation rules, normalizer.AnnotationRules if nil.
+	Rules *ann.Rule
+	// Pool runs the native drivers, instead of Native and Args. If nil, a
+	// single native driver is shared by the calls with the same command.
+	Pool *Pool
+	// Cache stores the native ASTs and UASTs, if not nil. Failures writing to
+	// it are ignored.
+	Cache *Cache
+}
+
+func (o *Options) pool() *Pool {
+	if o != nil && o.Pool != nil {
+		return o.Pool
+	}
+
+	return natives.get(o)
+}
+
+func (o *Options) command() []string {
+	name := DefaultNative
+	if o != nil && o.Native != "" {
+		name = o.Native
+	}
+
+	var args []string
+	if o != nil {
+		args = o.Args
+	}
+
+	return append([]string{name}, args...)
+}
+
+// Parse parses the source of a Python file into a UAST, annotated and
+// transformed as the driver does. The source is decoded following its PEP 263
+// coding cookie and the positions refer to the original source. opts may be
+// nil. See ParseNative for normalizer.ModeRecover, and ParseVersions for
+// normalizer.ModeDual.
+func Parse(ctx context.Context, source []byte, opts *Options) (*uast.Node, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	cache := opts.Cache
+	if cache != nil && !cache.resolve(ctx, opts) {
+		cache = nil
+	}
+
+	if cache != nil {
+		if n := cache.getUAST(source, opts); n != nil {
+			return n, nil
+		}
+	}
+
+	var n *uast.Node
+	if opts.Mode&normalizer.ModeDual != 0 {
+		vs, err := ParseVersions(ctx, source, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		n = vs.Node()
+	} else {
+		src, err := pep263.Decode(source)
+		if err != nil {
+			return nil, err
+		}
+
+		ast, err := parseOrRecover(ctx, src.Content, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if n, err = toUAST(src, ast, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if cache != nil {
+		_ = cache.putUAST(source, opts, n)
+	}
+
+	return n, nil
+}
+
+// toUAST converts a native AST of a source into a UAST.
+func toUAST(src *pep263.Source, ast map[string]interface{}, opts *Options) (*uast.Node, error) {
+	n, err := normalizer.ToNodeFor(opts.Mode).ToNode(ast)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := opts.Rules
+	if rules == nil {
+		rules = normalizer.AnnotationRules
+	}
+
+	for _, t := range normalizer.TransformersWithRules(opts.Mode, rules) {
+		if err := t.Do(src.Content, protocol.UTF8, n); err != nil {
+			return nil, ErrTransform.Wrap(err)
+		}
+	}
+
+	src.Remap(n)
+	return n, nil
+}
+
+// ParseNative parses the source of a Python file and returns the AST of the
+// native driver, as its root object with the PY2AST or PY3AST key. The source
+// is decoded following its PEP 263 coding cookie. opts may be nil.
+//
+// With normalizer.ModeRecover, the code with syntax errors is parsed by
+// top-level statements, and the ones that fail are replaced by
+// normalizer.Error nodes with the message of the errors, without failing.
+func ParseNative(ctx context.Context, source []byte, opts *Options) (map[string]interface{}, error) {
+	src, err := pep263.Decode(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseOrRecover(ctx, src.Content, opts)
+}
+
+// parseOrRecover parses the content with the native driver and, if it has
+// syntax errors and the Mode is normalizer.ModeRecover, parses its top-level
+// statements separately.
+func parseOrRecover(ctx context.Context, content string, opts *Options) (map[string]interface{}, error) {
+	ast, err := parseNative(ctx, content, opts)
+	if err != nil && opts != nil && opts.Mode&normalizer.ModeRecover != 0 && ErrSyntax.Is(err) {
+		return recoverNative(ctx, content, opts)
+	}
+
+	return ast, err
+}
+
+func parseNative(ctx context.Context, content string, opts *Options) (map[string]interface{}, error) {
+	var (
+		cache   *Cache
+		version int
+	)
+
+	if opts != nil {
+		cache, version = opts.Cache, opts.PythonVersion
+	}
+
+	if cache != nil && !cache.resolve(ctx, opts) {
+		cache = nil
+	}
+
+	if cache != nil {
+		if ast := cache.getNative(content, version); ast != nil {
+			return ast, nil
+		}
+	}
+
+	resp, err := parseResponse(ctx, content, opts, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ast, ok := resp.AST.(map[string]interface{})
+	if !ok {
+		return nil, ErrParse.New("no AST in the response")
+	}
+
+	if cache != nil {
+		_ = cache.putNative(content, version, ast)
+	}
+
+	return ast, nil
+}
+
+// parseResponse sends a request to the native driver, returning its response
+// if its status is ok.
+func parseResponse(ctx context.Context, content string, opts *Options, dual bool) (*response, error) {
+	req := &request{Dual: dual}
+	req.Content, req.Encoding = content, driver.Encoding(protocol.UTF8)
+	if opts != nil {
+		switch opts.PythonVersion {
+		case 0:
+		case 2, 3:
+			req.PythonVersion = strconv.Itoa(opts.PythonVersion)
+		default:
+			return nil, ErrPythonVersion.New(strconv.Itoa(opts.PythonVersion))
+		}
+	}
+
+	resp, err := opts.pool().parse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol.Status(resp.Status) != protocol.Ok {
+		var filename string
+		if opts != nil {
+			filename = opts.Filename
+		}
+
+		if es := ParseErrors(resp.Errors, filename, content); len(es) > 0 {
+			return nil, ErrSyntax.Wrap(es)
+		}
+
+		return nil, ErrParse.New(strings.Join(resp.Errors, "\n"))
+	}
+
+	return resp, nil
+}
+
+// ParsePythonVersion parses a Python version for Options.PythonVersion: "2",
+// "3", or "auto" or empty to detect it.
+func ParsePythonVersion(s string) (int, error) {
+	switch s {
+	case "", "auto":
+		return 0, nil
+	case "2", "3":
+		return strconv.Atoi(s)
+	}
+
+	return 0, ErrPythonVersion.New(s)
+}
+
+// Close stops the native drivers run by Parse and ParseNative without a Pool.
+// They're started again on the next call.
+func Close() error {
+	return natives.close()
+}
+
+// natives are the pools used without Options.Pool, by their command.
+var natives = &registry{pools: make(map[string]*Pool)}
+
+type registry struct {
+	m     sync.Mutex
+	pools map[string]*Pool
+}
+
+func (r *registry) get(opts *Options) *Pool {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	command := opts.command()
+	key := strings.Join(command, "\x00")
+	p, ok := r.pools[key]
+	if !ok {
+		p = NewPool(PoolConfig{Native: command[0], Args: command[1:], Size: 1})
+		r.pools[key] = p
+	}
+
+	return p
+}
+
+func (r *registry) close() error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	var first error
+	for key, p := range r.pools {
+		if err := p.Close(); err != nil && first == nil {
+			first = err
+		}
+
+		delete(r.pools, key)
+	}
+
+	return first
+}
@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/parser/fakenative"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+)
+
+var fixtureDir = filepath.Join("..", "..", "fixtures")
+
+func TestMain(m *testing.M) {
+	fakenative.Main(fixtureDir)
+	code := m.Run()
+	Close()
+	os.Exit(code)
+}
+
+const errorCode = "def f(:\n"
+
+func fakeOptions() *Options {
+	return &Options{Native: os.Args[0]}
+}
+
+func fixture(t *testing.T, name string) []byte {
+	src, err := ioutil.ReadFile(filepath.Join(fixtureDir, name))
+	require.NoError(t, err)
+	return src
+}
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	for _, name := range []string{"u2_import_rename.py", "issue_server101.py", "other_statements.py"} {
+		src := fixture(t, name)
+		n, err := Parse(context.Background(), src, fakeOptions())
+		require.NoError(err, name)
+
+		expected, err := ioutil.ReadFile(filepath.Join(fixtureDir, name+".uast"))
+		require.NoError(err)
+
+		resp := &protocol.ParseResponse{UAST: n}
+		resp.Language = "python"
+		resp.Status = protocol.Ok
+		require.Equal(string(expected), resp.String(), name)
+	}
+}
+
+func TestParseNative(t *testing.T) {
+	require := require.New(t)
+
+	ast, err := ParseNative(context.Background(), fixture(t, "u2_import_rename.py"), fakeOptions())
+	require.NoError(err)
+
+	module, ok := ast["PY3AST"].(map[string]interface{})
+	require.True(ok)
+	require.Equal("Module", module[normalizer.ToNode.InternalTypeKey])
+}
+
+func TestParseError(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Parse(context.Background(), []byte(errorCode), fakeOptions())
+	require.True(ErrSyntax.Is(err))
+	require.Contains(err.Error(), "SyntaxError")
+
+	_, err = ParseNative(context.Background(), []byte(errorCode), fakeOptions())
+	require.True(ErrSyntax.Is(err))
+}
+
+func TestParseNativeNotFound(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Parse(context.Background(), []byte(errorCode), &Options{Native: "./no-such-native"})
+	require.True(ErrNativeStart.Is(err))
+}
+
+func TestParseCancel(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := Parse(ctx, []byte(fakenative.Hang), fakeOptions())
+	require.Equal(context.DeadlineExceeded, err)
+
+	// the killed native driver is restarted
+	_, err = Parse(context.Background(), fixture(t, "u2_import_rename.py"), fakeOptions())
+	require.NoError(err)
+}
+
+func TestParseConcrete(t *testing.T) {
+	require := require.New(t)
+
+	files, err := filepath.Glob(filepath.Join(fixtureDir, "*.py"))
+	require.NoError(err)
+	require.NotEmpty(files)
+
+	opts := fakeOptions()
+	opts.Mode = normalizer.ModeConcrete
+	for _, f := range files {
+		src, err := ioutil.ReadFile(f)
+		require.NoError(err)
+		if len(src) == 0 {
+			// no AST to attach it to
+			continue
+		}
+
+		n, err := Parse(context.Background(), src, opts)
+		require.NoError(err, f)
+		require.Equal(string(src), normalizer.Regenerate(n), f)
+	}
+}
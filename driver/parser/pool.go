@@ -0,0 +1,198 @@
+package parser
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var (
+	// ErrPoolClosed is returned by the requests to a closed Pool.
+	ErrPoolClosed = errors.NewKind("pool closed")
+	// ErrPoolBusy is returned when all the workers of a Pool are busy and
+	// there are already PoolConfig.MaxWaiting requests waiting.
+	ErrPoolBusy = errors.NewKind("all the native drivers are busy")
+	// ErrNativeTimeout is returned when the native driver doesn't answer in
+	// the PoolConfig.Timeout.
+	ErrNativeTimeout = errors.NewKind("native driver didn't answer in %s")
+)
+
+// PoolConfig is the configuration of a Pool.
+type PoolConfig struct {
+	// Native is the command of the native driver, DefaultNative if empty.
+	Native string
+	// Args are the arguments of the native driver command.
+	Args []string
+	// Size is the number of native drivers, the number of CPUs if 0.
+	Size int
+	// Timeout is the maximum duration of a request, after which the native
+	// driver is killed. No timeout if 0.
+	Timeout time.Duration
+	// MaxRequests is the number of requests after which a native driver is
+	// restarted. Never restarted if 0.
+	MaxRequests int
+	// MaxRSS is the resident set size in bytes after which a native driver is
+	// restarted, checked after each request. Never restarted if 0.
+	MaxRSS int64
+	// MaxWaiting is the number of requests waiting for a native driver when
+	// all are busy, after which ErrPoolBusy is returned. The requests wait
+	// until their context is done if 0.
+	MaxWaiting int
+}
+
+// PoolStats are the counters of a Pool.
+type PoolStats struct {
+	// Started is the number of native drivers started.
+	Started int64
+	// Killed is the number of native drivers killed after failing, timing
+	// out or being cancelled.
+	Killed int64
+	// Recycled is the number of native drivers stopped after MaxRequests or
+	// MaxRSS.
+	Recycled int64
+}
+
+// Pool is a pool of native drivers serving requests concurrently, one at a
+// time each. The native drivers are started on demand and restarted when they
+// crash, hang or are recycled. It's safe for concurrent use.
+type Pool struct {
+	cfg     PoolConfig
+	command []string
+
+	idle      chan *worker
+	waiting   int64
+	closed    chan struct{}
+	closeOnce sync.Once
+	stats     PoolStats
+}
+
+type worker struct {
+	native   *native
+	requests int
+}
+
+// NewPool returns a new Pool, without starting any native driver.
+func NewPool(cfg PoolConfig) *Pool {
+	if cfg.Size <= 0 {
+		cfg.Size = runtime.NumCPU()
+	}
+
+	p := &Pool{
+		cfg:     cfg,
+		command: (&Options{Native: cfg.Native, Args: cfg.Args}).command(),
+		idle:    make(chan *worker, cfg.Size),
+		closed:  make(chan struct{}),
+	}
+
+	for i := 0; i < cfg.Size; i++ {
+		p.idle <- &worker{}
+	}
+
+	return p
+}
+
+// Stats returns the counters of the pool.
+func (p *Pool) Stats() PoolStats {
+	return PoolStats{
+		Started:  atomic.LoadInt64(&p.stats.Started),
+		Killed:   atomic.LoadInt64(&p.stats.Killed),
+		Recycled: atomic.LoadInt64(&p.stats.Recycled),
+	}
+}
+
+// Close stops the native drivers, waiting for the requests in progress.
+func (p *Pool) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closed)
+		for i := 0; i < p.cfg.Size; i++ {
+			w := <-p.idle
+			if w.native != nil {
+				w.native.stop()
+			}
+		}
+	})
+
+	return nil
+}
+
+func (p *Pool) parse(ctx context.Context, req *request) (*response, error) {
+	w, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { p.idle <- w }()
+	if w.native == nil || !w.native.alive() {
+		n, err := startNative(p.command[0], p.command[1:]...)
+		if err != nil {
+			w.native = nil
+			return nil, err
+		}
+
+		atomic.AddInt64(&p.stats.Started, 1)
+		w.native, w.requests = n, 0
+	}
+
+	rctx := ctx
+	if p.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		rctx, cancel = context.WithTimeout(ctx, p.cfg.Timeout)
+		defer cancel()
+	}
+
+	resp, err := w.native.parse(rctx, req)
+	if err != nil {
+		w.native.kill()
+		w.native = nil
+		atomic.AddInt64(&p.stats.Killed, 1)
+		if err == context.DeadlineExceeded && ctx.Err() == nil {
+			err = ErrNativeTimeout.New(p.cfg.Timeout)
+		}
+
+		return nil, err
+	}
+
+	w.requests++
+	if p.cfg.MaxRequests > 0 && w.requests >= p.cfg.MaxRequests ||
+		p.cfg.MaxRSS > 0 && w.native.rss() > p.cfg.MaxRSS {
+		w.native.stop()
+		w.native = nil
+		atomic.AddInt64(&p.stats.Recycled, 1)
+	}
+
+	return resp, nil
+}
+
+// acquire waits for an idle worker.
+func (p *Pool) acquire(ctx context.Context) (*worker, error) {
+	select {
+	case <-p.closed:
+		return nil, ErrPoolClosed.New()
+	default:
+	}
+
+	select {
+	case w := <-p.idle:
+		return w, nil
+	default:
+	}
+
+	waiting := atomic.AddInt64(&p.waiting, 1)
+	defer atomic.AddInt64(&p.waiting, -1)
+	if p.cfg.MaxWaiting > 0 && waiting > int64(p.cfg.MaxWaiting) {
+		return nil, ErrPoolBusy.New()
+	}
+
+	select {
+	case w := <-p.idle:
+		return w, nil
+	case <-p.closed:
+		return nil, ErrPoolClosed.New()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
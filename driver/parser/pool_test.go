@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bblfsh/python-driver/driver/parser/fakenative"
+	"github.com/stretchr/testify/require"
+)
+
+func fakePool(cfg PoolConfig) *Pool {
+	cfg.Native = os.Args[0]
+	return NewPool(cfg)
+}
+
+func pid(t *testing.T, p *Pool, code string) float64 {
+	ast, err := ParseNative(context.Background(), []byte(code), &Options{Pool: p})
+	require.NoError(t, err)
+	return ast["pid"].(float64)
+}
+
+func TestPoolConcurrent(t *testing.T) {
+	require := require.New(t)
+
+	p := fakePool(PoolConfig{Size: 3})
+	defer p.Close()
+
+	src := fixture(t, "u2_import_rename.py")
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Parse(context.Background(), src, &Options{Pool: p})
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(err)
+	}
+
+	require.True(p.Stats().Started <= 3)
+}
+
+func TestPoolRestartCrashed(t *testing.T) {
+	require := require.New(t)
+
+	p := fakePool(PoolConfig{Size: 1})
+	defer p.Close()
+
+	first := pid(t, p, fakenative.PID)
+	require.Equal(first, pid(t, p, fakenative.PID))
+
+	_, err := ParseNative(context.Background(), []byte(fakenative.Crash), &Options{Pool: p})
+	require.True(ErrNativeFailed.Is(err))
+
+	require.NotEqual(first, pid(t, p, fakenative.PID))
+	require.Equal(PoolStats{Started: 2, Killed: 1}, p.Stats())
+}
+
+func TestPoolTimeout(t *testing.T) {
+	require := require.New(t)
+
+	p := fakePool(PoolConfig{Size: 1, Timeout: 100 * time.Millisecond})
+	defer p.Close()
+
+	_, err := ParseNative(context.Background(), []byte(fakenative.Hang), &Options{Pool: p})
+	require.True(ErrNativeTimeout.Is(err))
+
+	pid(t, p, fakenative.PID)
+	require.Equal(PoolStats{Started: 2, Killed: 1}, p.Stats())
+}
+
+func TestPoolMaxRequests(t *testing.T) {
+	require := require.New(t)
+
+	p := fakePool(PoolConfig{Size: 1, MaxRequests: 2})
+	defer p.Close()
+
+	first := pid(t, p, fakenative.PID)
+	require.Equal(first, pid(t, p, fakenative.PID))
+	require.NotEqual(first, pid(t, p, fakenative.PID))
+	require.Equal(PoolStats{Started: 2, Recycled: 1}, p.Stats())
+}
+
+func TestPoolMaxRSS(t *testing.T) {
+	require := require.New(t)
+
+	if _, err := os.Stat("/proc/self/statm"); err != nil {
+		t.Skip("RSS not available")
+	}
+
+	p := fakePool(PoolConfig{Size: 1, MaxRSS: 64 << 20})
+	defer p.Close()
+
+	first := pid(t, p, fakenative.PID)
+	require.Equal(first, pid(t, p, fakenative.Grow+"128"))
+	require.NotEqual(first, pid(t, p, fakenative.PID))
+	require.Equal(int64(1), p.Stats().Recycled)
+}
+
+func TestPoolBusy(t *testing.T) {
+	require := require.New(t)
+
+	p := fakePool(PoolConfig{Size: 1, MaxWaiting: 1})
+	defer p.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hung := make(chan error)
+	go func() {
+		_, err := ParseNative(ctx, []byte(fakenative.Hang), &Options{Pool: p})
+		hung <- err
+	}()
+
+	for p.Stats().Started == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	waiting := make(chan error)
+	go func() {
+		_, err := ParseNative(context.Background(), []byte(fakenative.PID), &Options{Pool: p})
+		waiting <- err
+	}()
+
+	for atomic.LoadInt64(&p.waiting) == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, err := ParseNative(context.Background(), []byte(fakenative.PID), &Options{Pool: p})
+	require.True(ErrPoolBusy.Is(err))
+
+	cancel()
+	require.Equal(context.Canceled, <-hung)
+	require.NoError(<-waiting)
+}
+
+func TestPoolClosed(t *testing.T) {
+	require := require.New(t)
+
+	p := fakePool(PoolConfig{Size: 2})
+	pid(t, p, fakenative.PID)
+	require.NoError(p.Close())
+
+	_, err := ParseNative(context.Background(), []byte(fakenative.PID), &Options{Pool: p})
+	require.True(ErrPoolClosed.Is(err))
+}
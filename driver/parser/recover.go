@@ -0,0 +1,294 @@
+package parser
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+)
+
+// chunk is a top-level statement of a file, with the comments and blank lines
+// before it.
+type chunk struct {
+	// line is the first line of the chunk and code the first line of the
+	// statement, starting at 1.
+	line, code int
+	lines      []string
+}
+
+func (c *chunk) text() string {
+	return strings.Join(c.lines, "")
+}
+
+// continuations are the keywords of the clauses continuing a compound
+// statement.
+var continuations = []string{"else", "elif", "except", "finally"}
+
+// splitStatements splits the content in its top-level statements, using the
+// indentation and skipping the strings, brackets and escaped line breaks.
+// The comments and blank lines go with the next statement, unless they're at
+// the end.
+func splitStatements(content string) []*chunk {
+	var (
+		chunks []*chunk
+		cur    *chunk
+		// pending are the lines since the end of the last statement
+		pending []string
+		s       scanState
+		// decorated is set after a decorator, continued by its definition
+		decorated bool
+	)
+
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i, line := range lines {
+		logical := s.logicalStart()
+		if !logical && s.quote == "" && startsStatement(line) {
+			// an unclosed bracket, probably the syntax error
+			s, logical = scanState{}, true
+		}
+
+		s.scan(line)
+
+		trimmed := strings.TrimLeft(line, " \t\f")
+		blank := strings.TrimSpace(trimmed) == "" || strings.HasPrefix(trimmed, "#")
+		if blank && logical {
+			pending = append(pending, line)
+			continue
+		}
+
+		if logical && trimmed == line && !decorated && !isContinuation(trimmed) {
+			cur = &chunk{line: i + 1 - len(pending), code: i + 1}
+			chunks = append(chunks, cur)
+		} else if cur == nil {
+			cur = &chunk{line: i + 1 - len(pending), code: i + 1}
+			chunks = append(chunks, cur)
+		}
+
+		if logical && trimmed == line {
+			decorated = strings.HasPrefix(trimmed, "@")
+		}
+
+		cur.lines = append(cur.lines, pending...)
+		cur.lines = append(cur.lines, line)
+		pending = nil
+	}
+
+	if cur == nil {
+		return nil
+	}
+
+	cur.lines = append(cur.lines, pending...)
+	return chunks
+}
+
+// keywords are the keywords starting the statements, used to find them when
+// a bracket isn't closed.
+var keywords = []string{"@", "async", "class", "def", "for", "from", "if",
+	"import", "try", "while", "with"}
+
+// startsStatement returns true for the unindented lines starting with one of
+// the keywords.
+func startsStatement(line string) bool {
+	for _, kw := range keywords {
+		if strings.HasPrefix(line, kw) {
+			rest := line[len(kw):]
+			if kw == "@" || rest == "" || strings.IndexAny(rest[:1], " \t:(\r\n") == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isContinuation(line string) bool {
+	for _, kw := range continuations {
+		if strings.HasPrefix(line, kw) {
+			rest := line[len(kw):]
+			if rest == "" || strings.IndexAny(rest[:1], " \t:(\r\n#") == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// scanState is the state of the tokenizer between lines.
+type scanState struct {
+	// quote is the open string, as ''' or ", if any.
+	quote string
+	depth int
+	// escaped is set after a line ending with a backslash.
+	escaped bool
+}
+
+// logicalStart returns true if the next line starts a logical line.
+func (s *scanState) logicalStart() bool {
+	return s.quote == "" && s.depth == 0 && !s.escaped
+}
+
+// scan updates the state with the tokens of a line.
+func (s *scanState) scan(line string) {
+	s.escaped = false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		if s.quote != "" {
+			switch {
+			case c == '\\':
+				i++
+			case strings.HasPrefix(line[i:], s.quote):
+				i += len(s.quote) - 1
+				s.quote = ""
+			}
+
+			continue
+		}
+
+		switch c {
+		case '#':
+			return
+		case '\'', '"':
+			s.quote = string(c)
+			if strings.HasPrefix(line[i:], strings.Repeat(s.quote, 3)) {
+				s.quote = strings.Repeat(s.quote, 3)
+				i += 2
+			}
+		case '(', '[', '{':
+			s.depth++
+		case ')', ']', '}':
+			if s.depth > 0 {
+				s.depth--
+			}
+		case '\\':
+			if strings.TrimRight(line[i+1:], "\r\n") == "" {
+				s.escaped = true
+			}
+		}
+	}
+
+	// single quoted strings don't span lines, unless escaped
+	if len(s.quote) == 1 && !strings.HasSuffix(strings.TrimRight(line, "\r\n"), "\\") {
+		s.quote = ""
+	}
+}
+
+// recoverNative parses the top-level statements of a content with syntax
+// errors separately, returning a native AST with an Error node for each one
+// that fails. Unless opts sets it, the version of Python is detected once for
+// all of them: Python 2 if any statement is valid only for Python 2, as the
+// native driver prefers Python 3, and the statements answered for the other
+// version are parsed again with it.
+func recoverNative(ctx context.Context, content string, opts *Options) (map[string]interface{}, error) {
+	chunks := splitStatements(content)
+	asts := make([]map[string]interface{}, len(chunks))
+	errs := make([]error, len(chunks))
+	version := opts.PythonVersion
+	for i, c := range chunks {
+		asts[i], errs[i] = parseNative(ctx, c.text(), opts)
+		if errs[i] != nil && AsSyntaxErrors(errs[i]) == nil {
+			return nil, errs[i]
+		}
+
+		if _, ok := asts[i]["PY2AST"]; ok && version == 0 {
+			version = 2
+		}
+	}
+
+	if version == 0 {
+		version = 3
+	}
+
+	fixed := *opts
+	fixed.PythonVersion = version
+	key := "PY" + strconv.Itoa(version) + "AST"
+
+	module := map[string]interface{}{normalizer.ToNode.InternalTypeKey: "Module"}
+	var body []interface{}
+	for i, c := range chunks {
+		ast, err := asts[i], errs[i]
+		if err == nil && ast[key] == nil {
+			if ast, err = parseNative(ctx, c.text(), &fixed); err != nil && AsSyntaxErrors(err) == nil {
+				return nil, err
+			}
+		}
+
+		if err != nil {
+			body = append(body, errorNode(c, AsSyntaxErrors(err)))
+			continue
+		}
+
+		m, ok := ast[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		shiftLines(m, c.line-1)
+		if stmts, ok := m["body"].([]interface{}); ok {
+			body = append(body, stmts...)
+		}
+
+		if noops, ok := m["noops_remainder"]; ok {
+			module["noops_remainder"] = noops
+		}
+	}
+
+	module["body"] = body
+	return map[string]interface{}{key: module}, nil
+}
+
+// errorNode returns the Error node of a chunk, from its first line of code to
+// its last line.
+func errorNode(c *chunk, es SyntaxErrors) map[string]interface{} {
+	end := len(c.lines)
+	for end > c.code-c.line+1 && isBlankOrComment(c.lines[end-1]) {
+		end--
+	}
+
+	for _, e := range es {
+		e.Line += c.line - 1
+	}
+
+	// numbers as decoded from JSON
+	last := strings.TrimRight(c.lines[end-1], "\r\n")
+	return map[string]interface{}{
+		normalizer.ToNode.InternalTypeKey: normalizer.Error,
+		normalizer.ErrorMessageKey:        es.Error(),
+		"lineno":                          float64(c.code),
+		"col_offset":                      float64(1),
+		"end_lineno":                      float64(c.line + end - 1),
+		"end_col_offset":                  float64(len(last)),
+	}
+}
+
+func isBlankOrComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// shiftLines adds n to the line numbers of a native AST.
+func shiftLines(v interface{}, n int) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, c := range v {
+			if k == "lineno" || k == "end_lineno" {
+				if l, ok := c.(float64); ok {
+					v[k] = l + float64(n)
+				}
+
+				continue
+			}
+
+			shiftLines(c, n)
+		}
+	case []interface{}:
+		for _, c := range v {
+			shiftLines(c, n)
+		}
+	}
+}
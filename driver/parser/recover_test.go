@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func TestSplitStatements(t *testing.T) {
+	require := require.New(t)
+
+	content := "#!/usr/bin/env python\n" +
+		"import os\n" +
+		"\n" +
+		"# a comment\n" +
+		"@decorator\n" +
+		"def f(a,\n" +
+		"b):\n" +
+		"    '''doc\n" +
+		"x = 1\n" +
+		"'''\n" +
+		"    return a + \\\n" +
+		"b\n" +
+		"try:\n" +
+		"    pass\n" +
+		"except:\n" +
+		"    pass\n" +
+		"else :\n" +
+		"    pass\n" +
+		"s = 'a#'  # (\n" +
+		"elsewhere = 1\n" +
+		"# trailing\n"
+
+	chunks := splitStatements(content)
+	var texts []string
+	var lines []int
+	for _, c := range chunks {
+		texts = append(texts, c.text())
+		lines = append(lines, c.line, c.code)
+	}
+
+	require.Equal([]string{
+		"#!/usr/bin/env python\nimport os\n",
+		"\n# a comment\n@decorator\ndef f(a,\nb):\n    '''doc\nx = 1\n'''\n    return a + \\\nb\n",
+		"try:\n    pass\nexcept:\n    pass\nelse :\n    pass\n",
+		"s = 'a#'  # (\n",
+		"elsewhere = 1\n# trailing\n",
+	}, texts)
+	require.Equal([]int{1, 2, 3, 5, 13, 13, 19, 19, 20, 20}, lines)
+
+	chunks = splitStatements("x = f(\nimport os\n")
+	require.Len(chunks, 2)
+	require.Equal("import os\n", chunks[1].text())
+
+	require.Nil(splitStatements(""))
+	require.Nil(splitStatements("# just a comment\n"))
+}
+
+func TestParseRecover(t *testing.T) {
+	require := require.New(t)
+
+	opts := fakeOptions()
+	opts.Mode = normalizer.ModeRecover | normalizer.ModeValidateStrict
+	opts.Filename = "f.py"
+	src := string(fixture(t, "hello.py")) + "def f(:\n    pass\n\n" + string(fixture(t, "for.py"))
+	n, err := Parse(context.Background(), []byte(src), opts)
+	require.NoError(err)
+	require.Len(n.Children, 3)
+
+	hello, errNode, loop := n.Children[0], n.Children[1], n.Children[2]
+	require.Equal("Expr", hello.InternalType)
+	require.Equal(uint32(1), hello.StartPosition.Line)
+
+	require.Equal(normalizer.Error, errNode.InternalType)
+	require.Contains(errNode.Roles, uast.Incomplete)
+	require.Equal("f.py:2:7: SyntaxError: invalid syntax (Python 2, 3)", errNode.Properties[normalizer.ErrorMessageKey])
+	require.Equal(uint32(2), errNode.StartPosition.Line)
+	require.Equal(uint32(len(fixture(t, "hello.py"))), errNode.StartPosition.Offset)
+	require.Equal(uint32(3), errNode.EndPosition.Line)
+
+	require.Equal("For", loop.InternalType)
+	require.Equal(uint32(5), loop.StartPosition.Line)
+	var last uint32
+	iter := uast.NewOrderPathIter(uast.NewPath(loop))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		if pos := p.Node().StartPosition; pos != nil && pos.Line > last {
+			last = pos.Line
+		}
+	}
+
+	require.Equal(uint32(7), last)
+
+	_, err = Parse(context.Background(), []byte(src), fakeOptions())
+	require.True(ErrSyntax.Is(err))
+}
+
+func TestParseRecoverVersion(t *testing.T) {
+	require := require.New(t)
+
+	// a Python 2 statement, a Python 3 one and a syntax error
+	opts := fakeOptions()
+	opts.Mode = normalizer.ModeRecover | normalizer.ModeValidateStrict
+	src := string(fixture(t, "print_statement.py")) + string(fixture(t, "ellipsis.py")) + "def f(:\n    pass\n"
+	n, err := Parse(context.Background(), []byte(src), opts)
+	require.NoError(err)
+	require.Equal("2", n.Properties[normalizer.PythonVersionKey])
+	require.Len(n.Children, 3)
+
+	require.Equal("Print", n.Children[0].InternalType)
+	// valid only for Python 3
+	require.Equal(normalizer.Error, n.Children[1].InternalType)
+	require.Equal(uint32(2), n.Children[1].StartPosition.Line)
+	require.Equal(normalizer.Error, n.Children[2].InternalType)
+	require.Equal(uint32(3), n.Children[2].StartPosition.Line)
+}
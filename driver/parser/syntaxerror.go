@@ -0,0 +1,296 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// Kinds of SyntaxError, the names of the Python exceptions.
+const (
+	KindSyntax      = "SyntaxError"
+	KindIndentation = "IndentationError"
+	KindTab         = "TabError"
+)
+
+// SyntaxError is a syntax error reported by Python when parsing a file.
+type SyntaxError struct {
+	// Kind is KindSyntax, KindIndentation or KindTab.
+	Kind string
+	// File is the name of the file, "<unknown>" if not given.
+	File string
+	// Line and Column are the position of the error, starting at 1. Column
+	// is 0 if unknown.
+	Line, Column int
+	// Text is the line with the error, without the trailing newline.
+	Text string
+	// Msg is the message of the error.
+	Msg string
+	// Versions are the major versions of Python reporting the error.
+	Versions []int
+}
+
+// Error returns the error as FILE:LINE:COLUMN: KIND: MSG (Python VERSIONS).
+func (e *SyntaxError) Error() string {
+	pos := strconv.Itoa(e.Line)
+	if e.Column > 0 {
+		pos += ":" + strconv.Itoa(e.Column)
+	}
+
+	msg := fmt.Sprintf("%s:%s: %s: %s", e.File, pos, e.Kind, e.Msg)
+	if len(e.Versions) > 0 {
+		var vs []string
+		for _, v := range e.Versions {
+			vs = append(vs, strconv.Itoa(v))
+		}
+
+		msg += " (Python " + strings.Join(vs, ", ") + ")"
+	}
+
+	return msg
+}
+
+// IsIndentation returns true for the IndentationError and its subclass
+// TabError.
+func (e *SyntaxError) IsIndentation() bool {
+	return e.Kind == KindIndentation || e.Kind == KindTab
+}
+
+// SyntaxErrors are the syntax errors of a file, usually one for each Python
+// version, or a single one if they're the same.
+type SyntaxErrors []*SyntaxError
+
+// Error implements error.
+func (es SyntaxErrors) Error() string {
+	var msgs []string
+	for _, e := range es {
+		msgs = append(msgs, e.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// AsSyntaxErrors returns the syntax errors of an error returned by Parse or
+// ParseNative, nil if it's not a syntax error.
+func AsSyntaxErrors(err error) SyntaxErrors {
+	switch e := err.(type) {
+	case SyntaxErrors:
+		return e
+	case *errors.Error:
+		return AsSyntaxErrors(e.Cause())
+	}
+
+	return nil
+}
+
+var (
+	// versionHeader starts the errors of a Python version in the message of
+	// the native driver.
+	versionHeader = regexp.MustCompile(`(?m)^-+ Python(\d) errors:\n`)
+	// syntaxTraceback is the end of the traceback of a syntax error, with an
+	// optional text and caret.
+	syntaxTraceback = regexp.MustCompile(`(?m)^ *File "([^"\n]*)", line (\d+)\n` +
+		`(?:( *)([^\n]*)\n( *)\^+ *\n)?` +
+		`(SyntaxError|IndentationError|TabError): ([^\n]*)$`)
+	// shortError is a syntax error formatted with str(), without its kind,
+	// as "invalid syntax (<unknown>, line 1)".
+	shortError = regexp.MustCompile(`(?m)^([^\n]*) \(([^,\n]*), line (\d+)\)$`)
+)
+
+// ParseErrors extracts the syntax errors from the errors of a response of
+// the native driver, which are Python tracebacks. The source, if not empty,
+// gives the columns in the original lines. It returns nil if there are no
+// syntax errors.
+func ParseErrors(nativeErrors []string, filename, source string) SyntaxErrors {
+	var es SyntaxErrors
+	for _, text := range nativeErrors {
+		sections := versionHeader.FindAllStringSubmatchIndex(text, -1)
+		if len(sections) == 0 {
+			if e := parseTraceback(text, source); e != nil {
+				es = append(es, e)
+			}
+
+			continue
+		}
+
+		for i, s := range sections {
+			end := len(text)
+			if i+1 < len(sections) {
+				end = sections[i+1][0]
+			}
+
+			e := parseTraceback(unquoteList(text[s[1]:end]), source)
+			if e == nil {
+				continue
+			}
+
+			v, _ := strconv.Atoi(text[s[2]:s[3]])
+			e.Versions = []int{v}
+			es = append(es, e)
+		}
+	}
+
+	es = merge(es)
+	for _, e := range es {
+		if filename != "" {
+			e.File = filename
+		}
+	}
+
+	return es
+}
+
+// parseTraceback returns the last syntax error of a traceback.
+func parseTraceback(text, source string) *SyntaxError {
+	ms := syntaxTraceback.FindAllStringSubmatch(text, -1)
+	if len(ms) == 0 {
+		return parseShort(text, source)
+	}
+
+	m := ms[len(ms)-1]
+	e := &SyntaxError{File: m[1], Kind: m[6], Msg: m[7]}
+	e.Line, _ = strconv.Atoi(m[2])
+	e.Text = m[4]
+	if m[4] == "" && m[5] == "" {
+		return e
+	}
+
+	// the text is printed without its indentation, after the one of the
+	// traceback, as the caret
+	e.Column = len(m[5]) - len(m[3]) + 1
+	if line, ok := sourceLine(source, e.Line); ok {
+		trimmed := strings.TrimLeft(line, " \t\f")
+		e.Column += len(line) - len(trimmed)
+		e.Text = line
+	}
+
+	return e
+}
+
+// parseShort returns the last syntax error formatted with str(), guessing its
+// kind from the message.
+func parseShort(text, source string) *SyntaxError {
+	ms := shortError.FindAllStringSubmatch(text, -1)
+	if len(ms) == 0 {
+		return nil
+	}
+
+	m := ms[len(ms)-1]
+	e := &SyntaxError{Kind: KindSyntax, File: m[2], Msg: m[1]}
+	switch {
+	case strings.Contains(e.Msg, "tabs"):
+		e.Kind = KindTab
+	case strings.Contains(e.Msg, "indent"):
+		e.Kind = KindIndentation
+	}
+
+	e.Line, _ = strconv.Atoi(m[3])
+	e.Text, _ = sourceLine(source, e.Line)
+	return e
+}
+
+func sourceLine(source string, n int) (string, bool) {
+	if source == "" || n < 1 {
+		return "", false
+	}
+
+	lines := strings.SplitAfter(source, "\n")
+	if n > len(lines) {
+		return "", false
+	}
+
+	return strings.TrimRight(lines[n-1], "\r\n"), true
+}
+
+// merge merges the errors that are the same for several Python versions.
+func merge(es SyntaxErrors) SyntaxErrors {
+	var merged SyntaxErrors
+	for _, e := range es {
+		found := false
+		for _, m := range merged {
+			if m.Kind == e.Kind && m.Line == e.Line && m.Column == e.Column && m.Msg == e.Msg {
+				m.Versions = append(m.Versions, e.Versions...)
+				sort.Ints(m.Versions)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			merged = append(merged, e)
+		}
+	}
+
+	return merged
+}
+
+// unquoteList returns the text of the errors of a Python version, which may
+// be formatted as a Python list of strings, as ['Traceback...\n'].
+func unquoteList(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+		return text
+	}
+
+	var items []string
+	rest := text[1 : len(text)-1]
+	for {
+		rest = strings.TrimLeft(rest, " ,\n")
+		if rest == "" || (rest[0] != '\'' && rest[0] != '"') {
+			break
+		}
+
+		s, n := unquotePython(rest)
+		items = append(items, s)
+		rest = rest[n:]
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// unquotePython decodes the Python string literal at the start of s, quoted
+// with ' or ", returning it and its length.
+func unquotePython(s string) (string, int) {
+	quote := s[0]
+	var out []byte
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			return string(out), i + 1
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				out = append(out, '\n')
+			case 't':
+				out = append(out, '\t')
+			case 'r':
+				out = append(out, '\r')
+			case 'x', 'u':
+				// code points, as \xe9 or \u20ac
+				n := 2
+				if s[i] == 'u' {
+					n = 4
+				}
+
+				if i+n < len(s) {
+					if v, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32); err == nil {
+						out = append(out, string(rune(v))...)
+						i += n
+					}
+				}
+			default:
+				out = append(out, s[i])
+			}
+		default:
+			out = append(out, c)
+		}
+	}
+
+	return string(out), len(s)
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const nativeTraceback = `Traceback (most recent call last):
+  File "requestprocessor.py", line 136, in process_request
+Exception: Errors produced trying to get an AST for both Python versions
+------ Python2 errors:
+%s
+------ Python3 errors:
+%s
+`
+
+func nativeErrors(py2, py3 string) []string {
+	return []string{fmt.Sprintf(nativeTraceback, py2, py3)}
+}
+
+func TestParseErrorsSameError(t *testing.T) {
+	require := require.New(t)
+
+	source := "if True:\n    def f(:\n        pass\n"
+	py := `['  File "<unknown>", line 2\n    def f(:\n          ^\nSyntaxError: invalid syntax\n']`
+	es := ParseErrors(nativeErrors(py, py), "a.py", source)
+	require.Len(es, 1)
+
+	e := es[0]
+	require.Equal(KindSyntax, e.Kind)
+	require.Equal("a.py", e.File)
+	require.Equal(2, e.Line)
+	require.Equal(11, e.Column)
+	require.Equal("    def f(:", e.Text)
+	require.Equal("invalid syntax", e.Msg)
+	require.Equal([]int{2, 3}, e.Versions)
+	require.False(e.IsIndentation())
+	require.Equal("a.py:2:11: SyntaxError: invalid syntax (Python 2, 3)", es.Error())
+}
+
+func TestParseErrorsIndentation(t *testing.T) {
+	require := require.New(t)
+
+	source := "def f():\nreturn 1\n"
+	py2 := `['  File "<unknown>", line 2\n    return 1\n         ^\nIndentationError: expected an indented block\n']`
+	py3 := "Traceback (most recent call last):\n" +
+		"  File \"<unknown>\", line 2\n" +
+		"    return 1\n" +
+		"         ^\n" +
+		"IndentationError: expected an indented block\n"
+
+	es := ParseErrors(nativeErrors(py2, py3), "", source)
+	require.Len(es, 1)
+	require.Equal(KindIndentation, es[0].Kind)
+	require.True(es[0].IsIndentation())
+	require.Equal("<unknown>", es[0].File)
+	require.Equal(2, es[0].Line)
+	require.Equal(6, es[0].Column)
+	require.Equal([]int{2, 3}, es[0].Versions)
+}
+
+func TestParseErrorsTab(t *testing.T) {
+	require := require.New(t)
+
+	source := "if True:\n        x = 1\n\ty = 2\n"
+	py3 := `['  File "<unknown>", line 3\n    y = 2\n        ^\nTabError: inconsistent use of tabs and spaces in indentation\n']`
+	es := ParseErrors(nativeErrors("[]", py3), "", source)
+	require.Len(es, 1)
+	require.Equal(KindTab, es[0].Kind)
+	require.True(es[0].IsIndentation())
+	require.Equal(3, es[0].Line)
+	require.Equal("\ty = 2", es[0].Text)
+	require.Equal([]int{3}, es[0].Versions)
+}
+
+func TestParseErrorsDifferent(t *testing.T) {
+	require := require.New(t)
+
+	source := "print 'a'\n"
+	py2 := `['invalid syntax (<unknown>, line 1)']`
+	py3 := `['  File "<unknown>", line 1\n    print \'a\'\n            ^\nSyntaxError: Missing parentheses in call to \'print\'\n']`
+	es := ParseErrors(nativeErrors(py2, py3), "", source)
+	require.Len(es, 2)
+
+	require.Equal(KindSyntax, es[0].Kind)
+	require.Equal(1, es[0].Line)
+	require.Equal(0, es[0].Column)
+	require.Equal("print 'a'", es[0].Text)
+	require.Equal([]int{2}, es[0].Versions)
+	require.Equal("<unknown>:1: SyntaxError: invalid syntax (Python 2)", es[0].Error())
+
+	require.Equal("Missing parentheses in call to 'print'", es[1].Msg)
+	require.Equal(9, es[1].Column)
+	require.Equal([]int{3}, es[1].Versions)
+}
+
+func TestParseErrorsNone(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(ParseErrors([]string{"Code field empty"}, "", ""))
+	require.Nil(ParseErrors(nil, "", ""))
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	require := require.New(t)
+
+	opts := fakeOptions()
+	opts.Filename = "f.py"
+	_, err := Parse(context.Background(), []byte(errorCode), opts)
+	require.True(ErrSyntax.Is(err))
+
+	es := AsSyntaxErrors(err)
+	require.Len(es, 1)
+	require.Equal("f.py", es[0].File)
+	require.Equal(1, es[0].Line)
+	require.Equal(7, es[0].Column)
+	require.Equal("def f(:", es[0].Text)
+	require.Equal([]int{2, 3}, es[0].Versions)
+	require.Equal("syntax error: f.py:1:7: SyntaxError: invalid syntax (Python 2, 3)", err.Error())
+}
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/bblfsh/python-driver/driver/pep263"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Versions are the UASTs of a source for both versions of Python, returned by
+// ParseVersions.
+type Versions struct {
+	// Version is the version detected, 2 or 3, with a UAST.
+	Version int
+	// PY2 and PY3 are the UASTs of each version, nil if the code isn't valid
+	// for it.
+	PY2, PY3 *uast.Node
+	// Errors are the syntax errors of the version without UAST, if any.
+	Errors SyntaxErrors
+	// Confidence is the confidence of the detector on the code being of each
+	// version, from 0 to 1, by version.
+	Confidence map[int]float64
+}
+
+// ParseVersions parses the source of a Python file with both versions of
+// Python, as Parse. It fails only if neither can parse it. The Cache isn't
+// used and Options.PythonVersion is ignored.
+func ParseVersions(ctx context.Context, source []byte, opts *Options) (*Versions, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	src, err := pep263.Decode(source)
+	if err != nil {
+		return nil, err
+	}
+
+	o := *opts
+	o.PythonVersion = 0
+	resp, err := parseResponse(ctx, src.Content, &o, true)
+	if err != nil {
+		return nil, err
+	}
+
+	asts, ok := resp.AST.(map[string]interface{})
+	if !ok || len(asts) == 0 {
+		return nil, ErrParse.New("no AST in the response")
+	}
+
+	vs := &Versions{
+		Errors:     ParseErrors(resp.Errors, opts.Filename, src.Content),
+		Confidence: make(map[int]float64),
+	}
+
+	for v, c := range resp.Metadata.Confidence {
+		if n, err := strconv.Atoi(v); err == nil {
+			vs.Confidence[n] = c
+		}
+	}
+
+	for key, root := range asts {
+		n, err := toUAST(src, map[string]interface{}{key: root}, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "PY2AST":
+			vs.PY2 = n
+		case "PY3AST":
+			vs.PY3 = n
+		}
+	}
+
+	vs.Version = 3
+	if vs.PY3 == nil || vs.PY2 != nil && vs.Confidence[2] > vs.Confidence[3] {
+		vs.Version = 2
+	}
+
+	return vs, nil
+}
+
+// Node returns the UAST of the versions, the root node of normalizer.ModeDual.
+func (vs *Versions) Node() *uast.Node {
+	n := uast.NewNode()
+	n.InternalType = normalizer.Versions
+	n.Properties[normalizer.PythonVersionKey] = strconv.Itoa(vs.Version)
+	for _, v := range []int{2, 3} {
+		c := strconv.FormatFloat(vs.Confidence[v], 'f', -1, 64)
+		n.Properties[normalizer.ConfidenceKey+"."+strconv.Itoa(v)] = c
+	}
+
+	for i, module := range []*uast.Node{vs.PY2, vs.PY3} {
+		if module != nil {
+			module.Properties[uast.InternalRoleKey] = "PY" + strconv.Itoa(i+2) + "AST"
+			n.Children = append(n.Children, module)
+		}
+	}
+
+	return n
+}
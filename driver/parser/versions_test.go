@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersions(t *testing.T) {
+	require := require.New(t)
+
+	vs, err := ParseVersions(context.Background(), fixture(t, "u2_import_rename.py"), fakeOptions())
+	require.NoError(err)
+	require.Equal(3, vs.Version)
+	require.Nil(vs.PY2)
+	require.NotNil(vs.PY3)
+	require.Equal("3", vs.PY3.Properties[normalizer.PythonVersionKey])
+	require.Equal(map[int]float64{2: 0, 3: 1}, vs.Confidence)
+	require.Len(vs.Errors, 1)
+	require.Equal([]int{2}, vs.Errors[0].Versions)
+
+	vs, err = ParseVersions(context.Background(), fixture(t, "print.py"), fakeOptions())
+	require.NoError(err)
+	require.Equal(2, vs.Version)
+	require.NotNil(vs.PY2)
+	require.Nil(vs.PY3)
+
+	_, err = ParseVersions(context.Background(), []byte(errorCode), fakeOptions())
+	require.True(ErrSyntax.Is(err))
+}
+
+func TestParseDual(t *testing.T) {
+	require := require.New(t)
+
+	opts := fakeOptions()
+	opts.Mode = normalizer.ModeDual
+	n, err := Parse(context.Background(), fixture(t, "u2_import_rename.py"), opts)
+	require.NoError(err)
+	require.Equal(normalizer.Versions, n.InternalType)
+	require.Equal("3", n.Properties[normalizer.PythonVersionKey])
+	require.Equal("0", n.Properties["confidence.2"])
+	require.Equal("1", n.Properties["confidence.3"])
+	require.Len(n.Children, 1)
+	require.Equal("Module", n.Children[0].InternalType)
+	require.Equal("PY3AST", n.Children[0].Properties["internalRole"])
+}
+
+func TestParsePythonVersion(t *testing.T) {
+	require := require.New(t)
+
+	opts := fakeOptions()
+	opts.PythonVersion = 3
+	n, err := Parse(context.Background(), fixture(t, "u2_import_rename.py"), opts)
+	require.NoError(err)
+	require.Equal("3", n.Properties[normalizer.PythonVersionKey])
+
+	opts.PythonVersion = 2
+	_, err = Parse(context.Background(), fixture(t, "u2_import_rename.py"), opts)
+	require.True(ErrSyntax.Is(err))
+	require.Equal([]int{2}, AsSyntaxErrors(err)[0].Versions)
+
+	opts.PythonVersion = 4
+	_, err = Parse(context.Background(), fixture(t, "u2_import_rename.py"), opts)
+	require.True(ErrPythonVersion.Is(err))
+
+	for s, expected := range map[string]int{"": 0, "auto": 0, "2": 2, "3": 3} {
+		v, err := ParsePythonVersion(s)
+		require.NoError(err)
+		require.Equal(expected, v)
+	}
+
+	_, err = ParsePythonVersion("4")
+	require.True(ErrPythonVersion.Is(err))
+}
@@ -0,0 +1,177 @@
+// Package pep263 detects the source encoding of Python files as defined in
+// PEP 263 and transcodes them to UTF-8 before they're sent to the native
+// parser.
+//
+// https://www.python.org/dev/peps/pep-0263/
+package pep263
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// Names of the encodings that don't come from a coding cookie.
+const (
+	// UTF8 is the default source encoding for Python 3 files.
+	UTF8 = "utf-8"
+	// UTF8Sig is the encoding of files starting with a UTF-8 BOM.
+	UTF8Sig = "utf-8-sig"
+	// Fallback is the encoding used for files that are not valid UTF-8 and
+	// don't declare any encoding (usually Python 2 files written on Windows).
+	Fallback = "cp1252"
+)
+
+var (
+	utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+	// cookie is the regular expression given in PEP 263.
+	cookie = regexp.MustCompile(`^[ \t\f]*#.*?coding[:=][ \t]*([-\w.]+)`)
+	// blank matches lines that don't stop the search of the cookie in the
+	// second line: comments and lines with only whitespace.
+	blank = regexp.MustCompile(`^[ \t\f]*(?:[#\r\n]|$)`)
+)
+
+// codecs maps the normalized Python codec names (and their more common
+// aliases) to their encoding.
+var codecs = map[string]encoding.Encoding{
+	"utf_8":        unicode.UTF8,
+	"utf8":         unicode.UTF8,
+	"ascii":        unicode.UTF8,
+	"us_ascii":     unicode.UTF8,
+	"latin_1":      charmap.ISO8859_1,
+	"latin1":       charmap.ISO8859_1,
+	"iso_8859_1":   charmap.ISO8859_1,
+	"iso8859_1":    charmap.ISO8859_1,
+	"l1":           charmap.ISO8859_1,
+	"iso_8859_2":   charmap.ISO8859_2,
+	"latin2":       charmap.ISO8859_2,
+	"iso_8859_5":   charmap.ISO8859_5,
+	"iso_8859_7":   charmap.ISO8859_7,
+	"iso_8859_9":   charmap.ISO8859_9,
+	"latin5":       charmap.ISO8859_9,
+	"iso_8859_15":  charmap.ISO8859_15,
+	"latin9":       charmap.ISO8859_15,
+	"cp437":        charmap.CodePage437,
+	"cp850":        charmap.CodePage850,
+	"cp866":        charmap.CodePage866,
+	"cp1250":       charmap.Windows1250,
+	"windows_1250": charmap.Windows1250,
+	"cp1251":       charmap.Windows1251,
+	"windows_1251": charmap.Windows1251,
+	"cp1252":       charmap.Windows1252,
+	"windows_1252": charmap.Windows1252,
+	"cp1253":       charmap.Windows1253,
+	"cp1254":       charmap.Windows1254,
+	"koi8_r":       charmap.KOI8R,
+	"koi8_u":       charmap.KOI8U,
+	"mac_roman":    charmap.Macintosh,
+	"macroman":     charmap.Macintosh,
+	"shift_jis":    japanese.ShiftJIS,
+	"sjis":         japanese.ShiftJIS,
+	"euc_jp":       japanese.EUCJP,
+	"iso_2022_jp":  japanese.ISO2022JP,
+	"euc_kr":       korean.EUCKR,
+	"gb2312":       simplifiedchinese.GBK,
+	"gbk":          simplifiedchinese.GBK,
+	"gb18030":      simplifiedchinese.GB18030,
+	"big5":         traditionalchinese.Big5,
+}
+
+// Detect returns the normalized name of the encoding declared in the coding
+// cookie of the first two lines of src, UTF8Sig if src starts with a UTF-8 BOM
+// or an empty string if no encoding is declared.
+func Detect(src []byte) string {
+	if bytes.HasPrefix(src, utf8BOM) {
+		return UTF8Sig
+	}
+
+	first, rest := splitLine(src)
+	if name := match(first); name != "" {
+		return name
+	}
+
+	// the cookie is only allowed in the second line if the first one is a
+	// comment or blank (usually the shebang)
+	if !blank.Match(first) {
+		return ""
+	}
+
+	second, _ := splitLine(rest)
+	return match(second)
+}
+
+func splitLine(src []byte) (line, rest []byte) {
+	i := bytes.IndexAny(src, "\r\n")
+	if i < 0 {
+		return src, nil
+	}
+
+	if src[i] == '\r' && i+1 < len(src) && src[i+1] == '\n' {
+		return src[:i+2], src[i+2:]
+	}
+
+	return src[:i+1], src[i+1:]
+}
+
+// Cookie returns the normalized name of the encoding declared in the coding
+// cookie of a line, or an empty string if there's none.
+func Cookie(line string) string {
+	return match([]byte(line))
+}
+
+func match(line []byte) string {
+	m := cookie.FindSubmatch(line)
+	if m == nil {
+		return ""
+	}
+
+	return NormalName(string(m[1]))
+}
+
+// NormalName normalizes an encoding name the same way the CPython tokenizer
+// does, so "UTF-8", "utf_8" or "utf-8-unix" are all reported as "utf-8".
+func NormalName(name string) string {
+	n := strings.Replace(strings.ToLower(name), "_", "-", -1)
+	switch {
+	case n == "utf-8" || strings.HasPrefix(n, "utf-8-"):
+		return UTF8
+	case n == "latin-1" || n == "iso-8859-1" || n == "iso-latin-1",
+		strings.HasPrefix(n, "latin-1-"),
+		strings.HasPrefix(n, "iso-8859-1-"),
+		strings.HasPrefix(n, "iso-latin-1-"):
+		return "iso-8859-1"
+	}
+
+	return n
+}
+
+// Lookup returns the encoding for the given Python codec name. Unknown names
+// are looked up in the IANA index. It returns nil if the encoding isn't
+// supported.
+func Lookup(name string) encoding.Encoding {
+	if name == UTF8Sig {
+		return unicode.UTF8
+	}
+
+	key := strings.Replace(strings.Replace(strings.ToLower(name), "-", "_", -1), " ", "_", -1)
+	if e, ok := codecs[key]; ok {
+		return e
+	}
+
+	e, err := ianaindex.IANA.Encoding(name)
+	if err != nil || e == nil {
+		return nil
+	}
+
+	return e
+}
@@ -0,0 +1,98 @@
+package pep263
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func TestDetect(t *testing.T) {
+	require := require.New(t)
+
+	cases := map[string]string{
+		"print(1)\n":                                                   "",
+		"# -*- coding: latin-1 -*-\nprint(1)\n":                        "iso-8859-1",
+		"#!/usr/bin/env python\n# coding=cp1252\n":                     "cp1252",
+		"#!/usr/bin/env python\r\n# vim: set fileencoding=utf-8 :\r\n": "utf-8",
+		"# coding: UTF-8-unix\n":                                       "utf-8",
+		"import os\n# coding: latin-1\n":                               "",
+		"\n\n# coding: latin-1\n":                                      "",
+		"\xEF\xBB\xBFprint(1)\n":                                       UTF8Sig,
+	}
+
+	for src, expected := range cases {
+		require.Equal(expected, Detect([]byte(src)), "%q", src)
+	}
+}
+
+func TestDecodeUTF8(t *testing.T) {
+	require := require.New(t)
+
+	src, err := Decode([]byte("s = 'ñ'\n"))
+	require.NoError(err)
+	require.Equal(UTF8, src.Encoding)
+	require.Equal("s = 'ñ'\n", src.Content)
+	require.Equal(5, src.Original(5))
+}
+
+func TestDecodeLatin1(t *testing.T) {
+	require := require.New(t)
+
+	orig := []byte("# -*- coding: latin-1 -*-\ns = '\xF1'; t = 1\n")
+	src, err := Decode(orig)
+	require.NoError(err)
+	require.Equal("iso-8859-1", src.Encoding)
+	require.Equal("# -*- coding: utf-8 -*-\ns = 'ñ'; t = 1\n", src.Content)
+
+	// "t" in the transcoded and in the original content
+	require.Equal(byte('t'), src.Content[34])
+	require.Equal(byte('t'), orig[35])
+	require.Equal(35, src.Original(34))
+	require.Equal(len(orig), src.Original(len(src.Content)))
+}
+
+func TestDecodeFallback(t *testing.T) {
+	require := require.New(t)
+
+	src, err := Decode([]byte("s = '\x93quoted\x94'\n"))
+	require.NoError(err)
+	require.Equal(Fallback, src.Encoding)
+	require.Equal("s = '“quoted”'\n", src.Content)
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Decode([]byte("# coding: klingon\n"))
+	require.True(ErrUnknownEncoding.Is(err))
+}
+
+func TestRemap(t *testing.T) {
+	require := require.New(t)
+
+	src, err := Decode([]byte("# coding: latin-1\ns = '\xF1\xF1'; t = 1\n"))
+	require.NoError(err)
+
+	// "t" is at line 2, column 13 in the UTF-8 content but column 11 in the
+	// original one
+	n := uast.NewNode()
+	child := uast.NewNode()
+	child.StartPosition = &uast.Position{Offset: 28, Line: 2, Col: 13}
+	decl := uast.NewNode()
+	decl.Properties[EncodingKey] = UTF8
+	n.Children = append(n.Children, decl)
+	n.Children = append(n.Children, child)
+
+	src.Remap(n)
+	require.Equal("iso-8859-1", n.Properties[EncodingKey])
+	require.Equal(uast.Position{Offset: 28, Line: 2, Col: 11}, *child.StartPosition)
+	require.Equal("iso-8859-1", decl.Properties[EncodingKey])
+}
+
+func TestCookie(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("iso-8859-1", Cookie("# -*- coding: latin-1 -*-"))
+	require.Equal("", Cookie("import os"))
+}
@@ -0,0 +1,216 @@
+package pep263
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// EncodingKey is the property set on the root node with the name of the
+// original encoding of the file. It's also the property of the nodes of the
+// coding cookie declaration.
+const EncodingKey = "encoding"
+
+var (
+	// ErrUnknownEncoding is returned when the declared encoding is not
+	// supported.
+	ErrUnknownEncoding = errors.NewKind("unknown source encoding: %s")
+	// ErrDecoding is returned when the source can't be transcoded.
+	ErrDecoding = errors.NewKind("error decoding source as %s")
+)
+
+// Source is a Python file transcoded to UTF-8.
+type Source struct {
+	// Encoding is the normalized name of the encoding of the original file.
+	Encoding string
+	// Content is the file transcoded to UTF-8. The coding cookie, if any, is
+	// rewritten to declare "utf-8".
+	Content string
+	// offsets holds the offset in the original file for every byte of
+	// Content (plus its end). It's nil if the file was already UTF-8.
+	offsets []int
+}
+
+// Decode detects the encoding of src and transcodes it to UTF-8. Files without
+// a coding cookie are read as UTF-8 unless they aren't valid UTF-8, then the
+// Fallback encoding is used.
+func Decode(src []byte) (*Source, error) {
+	name := Detect(src)
+	if name == "" {
+		if utf8.Valid(src) {
+			return &Source{Encoding: UTF8, Content: string(src)}, nil
+		}
+
+		name = Fallback
+	}
+
+	enc := Lookup(name)
+	if enc == nil {
+		return nil, ErrUnknownEncoding.New(name)
+	}
+
+	if enc == unicode.UTF8 {
+		return &Source{Encoding: name, Content: string(src)}, nil
+	}
+
+	content, offsets, err := transcode(src, enc.NewDecoder())
+	if err != nil {
+		return nil, ErrDecoding.Wrap(err, name)
+	}
+
+	content, offsets = rewriteCookie(content, offsets)
+	return &Source{Encoding: name, Content: string(content), offsets: offsets}, nil
+}
+
+// transcode decodes src one character at a time to keep track of the offset
+// of every character in the original file.
+func transcode(src []byte, t transform.Transformer) ([]byte, []int, error) {
+	var (
+		out     = make([]byte, 0, len(src))
+		offsets = make([]int, 0, len(src)+1)
+		dst     [16]byte
+	)
+
+	for i := 0; i < len(src); {
+		end := i + 1
+		for {
+			atEOF := end == len(src)
+			nDst, nSrc, err := t.Transform(dst[:], src[i:end], atEOF)
+			if err == transform.ErrShortSrc && !atEOF {
+				end++
+				continue
+			}
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if nSrc == 0 {
+				return nil, nil, transform.ErrShortSrc
+			}
+
+			for j := 0; j < nDst; j++ {
+				offsets = append(offsets, i)
+			}
+
+			out = append(out, dst[:nDst]...)
+			i += nSrc
+			break
+		}
+	}
+
+	offsets = append(offsets, len(src))
+	return out, offsets, nil
+}
+
+// rewriteCookie replaces the encoding declared in the coding cookie with
+// "utf-8", since that's the encoding of the transcoded content.
+func rewriteCookie(content []byte, offsets []int) ([]byte, []int) {
+	first, rest := splitLine(content)
+	loc := cookie.FindSubmatchIndex(first)
+	if loc == nil && blank.Match(first) {
+		second, _ := splitLine(rest)
+		if loc = cookie.FindSubmatchIndex(second); loc != nil {
+			for i := range loc {
+				loc[i] += len(first)
+			}
+		}
+	}
+
+	if loc == nil {
+		return content, offsets
+	}
+
+	start, end := loc[2], loc[3]
+	name := []byte(UTF8)
+
+	var out bytes.Buffer
+	out.Write(content[:start])
+	out.Write(name)
+	out.Write(content[end:])
+
+	remapped := make([]int, 0, len(offsets)-(end-start)+len(name))
+	remapped = append(remapped, offsets[:start]...)
+	for i := range name {
+		// positions inside the name point to the original one, clamped to
+		// its last character
+		j := start + i
+		if j >= end {
+			j = end - 1
+		}
+
+		remapped = append(remapped, offsets[j])
+	}
+	remapped = append(remapped, offsets[end:]...)
+
+	return out.Bytes(), remapped
+}
+
+// Original returns the byte offset in the original file for the given byte
+// offset of Content.
+func (s *Source) Original(offset int) int {
+	if s.offsets == nil {
+		return offset
+	}
+
+	if offset < 0 {
+		return 0
+	}
+
+	if offset >= len(s.offsets) {
+		return s.offsets[len(s.offsets)-1]
+	}
+
+	return s.offsets[offset]
+}
+
+// Remap maps the positions of every node in n, computed over Content, back to
+// the original file and records the encoding in the root node.
+func (s *Source) Remap(n *uast.Node) {
+	if n == nil {
+		return
+	}
+
+	if n.Properties == nil {
+		n.Properties = make(map[string]string)
+	}
+
+	n.Properties[EncodingKey] = s.Encoding
+	if s.offsets == nil {
+		return
+	}
+
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for {
+		p := iter.Next()
+		if p.IsEmpty() {
+			break
+		}
+
+		n := p.Node()
+		// the cookie was rewritten to "utf-8" before parsing
+		if _, ok := n.Properties[EncodingKey]; ok {
+			n.Properties[EncodingKey] = s.Encoding
+		}
+
+		s.remap(n.StartPosition)
+		s.remap(n.EndPosition)
+	}
+}
+
+func (s *Source) remap(pos *uast.Position) {
+	if pos == nil {
+		return
+	}
+
+	offset := int(pos.Offset)
+	lineStart := offset - int(pos.Col) + 1
+	pos.Offset = uint32(s.Original(offset))
+	if pos.Col > 0 {
+		pos.Col = uint32(s.Original(offset) - s.Original(lineStart) + 1)
+	}
+}
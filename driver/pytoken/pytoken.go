@@ -0,0 +1,247 @@
+// Package pytoken splits Python source code in tokens without losing any byte
+// of it: whitespace, comments, line breaks and escaped line breaks are tokens
+// too, so the source is the concatenation of its tokens. Unlike the Python
+// tokenizer, it doesn't track the indentation nor fails on invalid code, whose
+// unknown characters become Error tokens.
+package pytoken
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind is the kind of a Token.
+type Kind int
+
+const (
+	// Error is a character that can't start a token, or invalid UTF-8.
+	Error Kind = iota
+	// Name is an identifier or a keyword.
+	Name
+	// Number is a numeric literal.
+	Number
+	// String is a string literal, with its prefix and quotes.
+	String
+	// Op is an operator or a delimiter.
+	Op
+	// Comment is a comment, without its line break.
+	Comment
+	// Newline is a line break: "\n", "\r\n" or "\r".
+	Newline
+	// Whitespace is a run of spaces, tabs and form feeds.
+	Whitespace
+	// Continuation is a backslash followed by a line break.
+	Continuation
+	// BOM is the UTF-8 byte order mark at the start of a file.
+	BOM
+)
+
+var kindNames = [...]string{"Error", "Name", "Number", "String", "Op",
+	"Comment", "Newline", "Whitespace", "Continuation", "BOM"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+
+	return kindNames[k]
+}
+
+// IsTrivia returns true for the tokens that don't take part in the syntax:
+// comments, whitespace, line breaks and the BOM.
+func (k Kind) IsTrivia() bool {
+	switch k {
+	case Comment, Newline, Whitespace, Continuation, BOM:
+		return true
+	}
+
+	return false
+}
+
+// Token is a token of a source.
+type Token struct {
+	Kind Kind
+	// Offset is the byte offset of the token in the source.
+	Offset int
+	Text   string
+}
+
+// End returns the offset of the byte after the token.
+func (t Token) End() int {
+	return t.Offset + len(t.Text)
+}
+
+// ops are the operators and delimiters, longest first.
+var ops = []string{
+	"**=", "//=", ">>=", "<<=", "...",
+	"->", ":=", "**", "//", ">>", "<<", "<=", ">=", "==", "!=", "<>",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "@=",
+	"+", "-", "*", "/", "%", "&", "|", "^", "~", "<", ">", "@", "=",
+	"(", ")", "[", "]", "{", "}", ",", ":", ".", ";", "`", "!",
+}
+
+// stringPrefixes are the prefixes of the string literals of both versions of
+// Python, lowercased.
+var stringPrefixes = map[string]bool{
+	"r": true, "u": true, "b": true, "f": true,
+	"br": true, "rb": true, "fr": true, "rf": true, "ur": true,
+}
+
+const bom = "\ufeff"
+
+// Tokenize returns the tokens of a source.
+func Tokenize(src string) []Token {
+	var tokens []Token
+	i := 0
+	if strings.HasPrefix(src, bom) {
+		tokens = append(tokens, Token{Kind: BOM, Text: bom})
+		i = len(bom)
+	}
+
+	for i < len(src) {
+		kind, end := scan(src, i)
+		tokens = append(tokens, Token{Kind: kind, Offset: i, Text: src[i:end]})
+		i = end
+	}
+
+	return tokens
+}
+
+// scan returns the kind and the end of the token starting at i.
+func scan(src string, i int) (Kind, int) {
+	c := src[i]
+	switch {
+	case c == '\n':
+		return Newline, i + 1
+	case c == '\r':
+		if i+1 < len(src) && src[i+1] == '\n' {
+			return Newline, i + 2
+		}
+
+		return Newline, i + 1
+	case c == ' ' || c == '\t' || c == '\f':
+		end := i + 1
+		for end < len(src) && (src[end] == ' ' || src[end] == '\t' || src[end] == '\f') {
+			end++
+		}
+
+		return Whitespace, end
+	case c == '#':
+		end := strings.IndexAny(src[i:], "\r\n")
+		if end < 0 {
+			return Comment, len(src)
+		}
+
+		return Comment, i + end
+	case c == '\\':
+		if i+1 < len(src) && (src[i+1] == '\n' || src[i+1] == '\r') {
+			_, end := scan(src, i+1)
+			return Continuation, end
+		}
+
+		return Error, i + 1
+	case c == '\'' || c == '"':
+		return String, scanString(src, i)
+	case c >= '0' && c <= '9' || c == '.' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9':
+		return Number, scanNumber(src, i)
+	}
+
+	if end := scanName(src, i); end > i {
+		if end < len(src) && (src[end] == '\'' || src[end] == '"') &&
+			stringPrefixes[strings.ToLower(src[i:end])] {
+			return String, scanString(src, end)
+		}
+
+		return Name, end
+	}
+
+	for _, op := range ops {
+		if strings.HasPrefix(src[i:], op) {
+			return Op, i + len(op)
+		}
+	}
+
+	_, size := utf8.DecodeRuneInString(src[i:])
+	return Error, i + size
+}
+
+// scanName returns the end of the identifier starting at i, or i if there's
+// none.
+func scanName(src string, i int) int {
+	end := i
+	for end < len(src) {
+		r, size := utf8.DecodeRuneInString(src[end:])
+		if !isNameRune(r) || end == i && unicode.IsDigit(r) {
+			break
+		}
+
+		end += size
+	}
+
+	return end
+}
+
+func isNameRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'
+	}
+
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r))
+}
+
+// scanNumber returns the end of the number starting at i: its digits, letters,
+// underscores and dots, and the signs of its exponent.
+func scanNumber(src string, i int) int {
+	hex := strings.HasPrefix(src[i:], "0x") || strings.HasPrefix(src[i:], "0X")
+	end := i
+	for end < len(src) {
+		c := src[end]
+		switch {
+		case c == '_' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+		case (c == '+' || c == '-') && !hex && (src[end-1] == 'e' || src[end-1] == 'E'):
+		default:
+			return end
+		}
+
+		end++
+	}
+
+	return end
+}
+
+// scanString returns the end of the string whose opening quote is at i. The
+// strings without their closing quote end at the line break, or at the end of
+// the source if triple quoted.
+func scanString(src string, i int) int {
+	quote := src[i : i+1]
+	if strings.HasPrefix(src[i:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+
+	end := i + len(quote)
+	for end < len(src) {
+		switch c := src[end]; {
+		case c == '\\':
+			// even in raw strings the backslash escapes the quotes
+			end += 2
+			if end > len(src) {
+				return len(src)
+			}
+
+			// the line break after it may be "\r\n"
+			if src[end-1] == '\r' && end < len(src) && src[end] == '\n' {
+				end++
+			}
+		case strings.HasPrefix(src[end:], quote):
+			return end + len(quote)
+		case (c == '\n' || c == '\r') && len(quote) == 1:
+			return end
+		default:
+			end++
+		}
+	}
+
+	return end
+}
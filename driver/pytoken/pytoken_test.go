@@ -0,0 +1,61 @@
+package pytoken
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	require := require.New(t)
+
+	src := "\ufeffif x:  # c\r\n" +
+		"\ty = rb'a\\'' + \"\"\"b\n\"\"\" \\\n" +
+		"  .5e-3 ** -0x1E+2j\n" +
+		"ñame $ 'open\n"
+
+	var kinds []Kind
+	var texts []string
+	for _, tk := range Tokenize(src) {
+		kinds = append(kinds, tk.Kind)
+		texts = append(texts, tk.Text)
+	}
+
+	require.Equal([]string{"\ufeff", "if", " ", "x", ":", "  ", "# c", "\r\n",
+		"\t", "y", " ", "=", " ", "rb'a\\''", " ", "+", " ", "\"\"\"b\n\"\"\"", " ", "\\\n",
+		"  ", ".5e-3", " ", "**", " ", "-", "0x1E", "+", "2j", "\n",
+		"ñame", " ", "$", " ", "'open", "\n"}, texts)
+	require.Equal([]Kind{BOM, Name, Whitespace, Name, Op, Whitespace, Comment, Newline,
+		Whitespace, Name, Whitespace, Op, Whitespace, String, Whitespace, Op, Whitespace, String, Whitespace, Continuation,
+		Whitespace, Number, Whitespace, Op, Whitespace, Op, Number, Op, Number, Newline,
+		Name, Whitespace, Error, Whitespace, String, Newline}, kinds)
+	require.Equal("Continuation", Continuation.String())
+	require.True(Comment.IsTrivia())
+	require.False(String.IsTrivia())
+}
+
+func TestTokenizeFixtures(t *testing.T) {
+	require := require.New(t)
+
+	files, err := filepath.Glob(filepath.Join("..", "..", "fixtures", "*.py"))
+	require.NoError(err)
+	require.NotEmpty(files)
+
+	for _, f := range files {
+		src, err := ioutil.ReadFile(f)
+		require.NoError(err)
+
+		var texts []string
+		end := 0
+		for _, tk := range Tokenize(string(src)) {
+			require.Equal(end, tk.Offset, f)
+			texts = append(texts, tk.Text)
+			end = tk.End()
+		}
+
+		require.Equal(string(src), strings.Join(texts, ""), f)
+	}
+}
@@ -0,0 +1,148 @@
+package pytypes
+
+import (
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Properties set by the Annotator with the canonical representation of the
+// declared types.
+const (
+	// TypeKey is set on the annotated parameters and assignment targets.
+	TypeKey = "type"
+	// ReturnTypeKey is set on the functions with an annotated return value.
+	ReturnTypeKey = "returnType"
+	// VarargTypeKey and KwargTypeKey are set on the arguments node of Python 2
+	// functions with annotated variable arguments, which are properties of it.
+	VarargTypeKey = "varargType"
+	KwargTypeKey  = "kwargType"
+)
+
+// DeclarationKind is the kind of an annotated declaration.
+type DeclarationKind int
+
+const (
+	// Parameter is an annotated function parameter.
+	Parameter DeclarationKind = iota
+	// Return is the annotated return value of a function.
+	Return
+	// Variable is an annotated assignment target.
+	Variable
+)
+
+// Declaration is an annotated parameter, return value or variable.
+type Declaration struct {
+	Kind DeclarationKind
+	// Node is the arg node of parameters (the Name or Tuple node in Python
+	// 2, or the arguments node for its variable arguments), the function of
+	// return values or the target of variables.
+	Node *uast.Node
+	// Role is the internal role of the annotation, as in Param.
+	Role string
+	Type *Type
+}
+
+// paramRoles are the internal roles of the parameters of a function, in the
+// order they're declared.
+var paramRoles = []string{"args", "vararg", "kwonlyargs", "kwarg"}
+
+// Param is a parameter of a function, annotated by the child of Node with the
+// internal role Role.
+type Param struct {
+	Node *uast.Node
+	Role string
+}
+
+// Params returns the parameters of a function from its arguments node, in
+// declaration order. The variable arguments of Python 2 are names in the
+// properties of n, annotated by its "varargannotation" and "kwargannotation"
+// children, as in the AST of Python 3.0 to 3.3.
+func Params(n *uast.Node) []Param {
+	var params []Param
+	for _, role := range paramRoles {
+		found := false
+		for _, c := range n.Children {
+			if c.Properties[uast.InternalRoleKey] == role {
+				params = append(params, Param{Node: c, Role: "annotation"})
+				found = true
+			}
+		}
+
+		if _, ok := n.Properties[role]; ok && !found {
+			params = append(params, Param{Node: n, Role: role + "annotation"})
+		}
+	}
+
+	return params
+}
+
+// Declarations returns the annotated declarations under n in source order,
+// including the ones annotated with type comments.
+func Declarations(n *uast.Node) []*Declaration {
+	var decls []*Declaration
+	iter := uast.NewOrderPathIter(uast.NewPath(n))
+	for p := iter.Next(); !p.IsEmpty(); p = iter.Next() {
+		n := p.Node()
+		switch n.InternalType {
+		case "arguments":
+			for _, param := range Params(n) {
+				if a := child(param.Node, param.Role); a != nil {
+					decls = append(decls, &Declaration{
+						Kind: Parameter, Node: param.Node, Role: param.Role, Type: Parse(a),
+					})
+				}
+			}
+		case "FunctionDef", "AsyncFunctionDef":
+			if a := child(n, "returns"); a != nil {
+				decls = append(decls, &Declaration{Kind: Return, Node: n, Role: "returns", Type: Parse(a)})
+			}
+		case "AnnAssign", "Assign":
+			a := child(n, "annotation")
+			if a == nil {
+				continue
+			}
+
+			t := Parse(a)
+			for _, c := range n.Children {
+				switch c.Properties[uast.InternalRoleKey] {
+				case "target", "targets":
+					decls = append(decls, &Declaration{Kind: Variable, Node: c, Role: "annotation", Type: t})
+				}
+			}
+		}
+	}
+
+	return decls
+}
+
+// Annotator is a `transformer.Tranformer` that sets the TypeKey and
+// ReturnTypeKey properties of the annotated declarations.
+type Annotator struct{}
+
+// NewAnnotator returns a new Annotator.
+func NewAnnotator() *Annotator {
+	return &Annotator{}
+}
+
+// Do implements `transformer.Tranformer`.
+func (t *Annotator) Do(code string, e protocol.Encoding, n *uast.Node) error {
+	for _, d := range Declarations(n) {
+		if d.Node.Properties == nil {
+			d.Node.Properties = make(map[string]string)
+		}
+
+		key := TypeKey
+		switch d.Role {
+		case "returns":
+			key = ReturnTypeKey
+		case "varargannotation":
+			key = VarargTypeKey
+		case "kwargannotation":
+			key = KwargTypeKey
+		}
+
+		d.Node.Properties[key] = d.Type.String()
+	}
+
+	return nil
+}
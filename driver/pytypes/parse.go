@@ -0,0 +1,209 @@
+package pytypes
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// forwardReference is the internal role of the types parsed from the string
+// annotations, normalizer.ForwardReference, which this package can't import.
+const forwardReference = "forward_reference"
+
+// typingModules are the modules whose special forms are recognized, as in
+// typing.Optional.
+var typingModules = []string{"typing.", "typing_extensions."}
+
+// Parse returns the Type of an annotation subtree: the "annotation" or
+// "returns" child of a declaration.
+func Parse(n *uast.Node) *Type {
+	if n == nil {
+		return nil
+	}
+
+	switch n.InternalType {
+	case "Name", "Attribute":
+		if name := dotted(n); name != "" {
+			return &Type{Kind: Name, Name: name}
+		}
+	case "NoneLiteral":
+		return &Type{Kind: None}
+	case "Ellipsis":
+		return &Type{Kind: Ellipsis}
+	case "Str":
+		// forward references parsed by the normalizer
+		if ref := child(n, forwardReference); ref != nil {
+			return Parse(ref)
+		}
+	case "BinOp":
+		if op := child(n, "op"); op != nil && op.InternalType == "BitOr" {
+			return union(Parse(child(n, "left")), Parse(child(n, "right")))
+		}
+	case "Subscript":
+		return subscript(n)
+	}
+
+	return &Type{Kind: Unknown}
+}
+
+func subscript(n *uast.Node) *Type {
+	name := dotted(child(n, "value"))
+	if name == "" {
+		return &Type{Kind: Unknown}
+	}
+
+	args := arguments(child(n, "slice"))
+	switch special(name) {
+	case "Optional":
+		if len(args) == 1 {
+			return union(Parse(args[0]), &Type{Kind: None})
+		}
+	case "Union":
+		var t *Type
+		for _, a := range args {
+			t = union(t, Parse(a))
+		}
+
+		if t != nil {
+			return t
+		}
+	case "Callable":
+		if len(args) != 2 {
+			break
+		}
+
+		t := &Type{Kind: Callable, Returns: Parse(args[1])}
+		if args[0].InternalType == "List" {
+			t.Args = []*Type{}
+			for _, a := range elements(args[0]) {
+				t.Args = append(t.Args, Parse(a))
+			}
+		}
+
+		return t
+	case "Literal":
+		t := &Type{Kind: Literal}
+		for _, a := range args {
+			t.Values = append(t.Values, literal(a))
+		}
+
+		return t
+	}
+
+	t := &Type{Kind: Generic, Name: name}
+	for _, a := range args {
+		t.Args = append(t.Args, Parse(a))
+	}
+
+	return t
+}
+
+// special returns the name of a typing special form without its module.
+func special(name string) string {
+	for _, m := range typingModules {
+		if strings.HasPrefix(name, m) {
+			return name[len(m):]
+		}
+	}
+
+	return name
+}
+
+// union returns the union of two types, flattening nested unions.
+func union(a, b *Type) *Type {
+	if a == nil {
+		return b
+	}
+
+	t := &Type{Kind: Union}
+	for _, m := range []*Type{a, b} {
+		if m.Kind == Union {
+			t.Args = append(t.Args, m.Args...)
+		} else {
+			t.Args = append(t.Args, m)
+		}
+	}
+
+	return t
+}
+
+// arguments returns the arguments of a subscript from its slice.
+func arguments(slice *uast.Node) []*uast.Node {
+	if slice == nil {
+		return nil
+	}
+
+	// Python < 3.9 wraps the subscript in an Index node
+	if slice.InternalType == "Index" {
+		slice = child(slice, "value")
+		if slice == nil {
+			return nil
+		}
+	}
+
+	if slice.InternalType == "Tuple" {
+		return elements(slice)
+	}
+
+	return []*uast.Node{slice}
+}
+
+func elements(n *uast.Node) []*uast.Node {
+	var elts []*uast.Node
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] == "elts" {
+			elts = append(elts, c)
+		}
+	}
+
+	return elts
+}
+
+// dotted returns the dotted name of Name and Attribute nodes.
+func dotted(n *uast.Node) string {
+	if n == nil {
+		return ""
+	}
+
+	switch n.InternalType {
+	case "Name":
+		return n.Token
+	case "Attribute":
+		if prefix := dotted(child(n, "value")); prefix != "" {
+			return prefix + "." + n.Token
+		}
+	}
+
+	return ""
+}
+
+// literal returns the source representation of the value of a literal node.
+func literal(n *uast.Node) string {
+	switch n.InternalType {
+	case "Str":
+		return strconv.Quote(n.Token)
+	case "UnaryOp":
+		// negative numbers
+		op, operand := child(n, "op"), child(n, "operand")
+		if op != nil && operand != nil {
+			return op.Token + literal(operand)
+		}
+	}
+
+	if n.Token != "" {
+		return n.Token
+	}
+
+	return dotted(n)
+}
+
+func child(n *uast.Node, role string) *uast.Node {
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] == role {
+			return c
+		}
+	}
+
+	return nil
+}
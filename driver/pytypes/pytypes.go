@@ -0,0 +1,121 @@
+// Package pytypes builds a structured model of the Python type annotations
+// (PEP 484, 586 and 604) from their UAST subtrees.
+package pytypes
+
+import (
+	"strings"
+)
+
+// Kind is the kind of a Type.
+type Kind int
+
+const (
+	// Unknown is an expression that's not a valid type.
+	Unknown Kind = iota
+	// Name is a plain type name: int, typing.List...
+	Name
+	// Generic is a subscripted type: List[int], Dict[str, Any]...
+	Generic
+	// Union is a Union[...], an Optional[...] (with None as its last
+	// argument) or a PEP 604 union (int | str).
+	Union
+	// Callable is a Callable[[...], ...].
+	Callable
+	// Literal is a Literal[...].
+	Literal
+	// None is the None type.
+	None
+	// Ellipsis is the "..." in Tuple[int, ...] or Callable[..., int].
+	Ellipsis
+)
+
+var kindNames = map[Kind]string{
+	Unknown:  "unknown",
+	Name:     "name",
+	Generic:  "generic",
+	Union:    "union",
+	Callable: "callable",
+	Literal:  "literal",
+	None:     "none",
+	Ellipsis: "ellipsis",
+}
+
+// String returns the name of the Kind.
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// Type is a Python type annotation.
+type Type struct {
+	Kind Kind
+	// Name is the name as written (maybe dotted) of Name and Generic types.
+	Name string
+	// Args are the arguments of Generic types, the members of Union types
+	// and the parameters of Callable types (nil for Callable[..., R]).
+	Args []*Type
+	// Returns is the return type of Callable types.
+	Returns *Type
+	// Values are the values of Literal types, as written in the source.
+	Values []string
+}
+
+// Optional returns true if the type is a Union including None.
+func (t *Type) Optional() bool {
+	if t == nil || t.Kind != Union {
+		return false
+	}
+
+	for _, a := range t.Args {
+		if a.Kind == None {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the canonical representation of the type: unions are written
+// as PEP 604 unions and the other types in the typing module notation.
+func (t *Type) String() string {
+	if t == nil {
+		return ""
+	}
+
+	switch t.Kind {
+	case Name:
+		return t.Name
+	case Generic:
+		return t.Name + "[" + join(t.Args) + "]"
+	case Union:
+		var members []string
+		for _, a := range t.Args {
+			members = append(members, a.String())
+		}
+
+		return strings.Join(members, " | ")
+	case Callable:
+		params := "..."
+		if t.Args != nil {
+			params = "[" + join(t.Args) + "]"
+		}
+
+		return "Callable[" + params + ", " + t.Returns.String() + "]"
+	case Literal:
+		return "Literal[" + strings.Join(t.Values, ", ") + "]"
+	case None:
+		return "None"
+	case Ellipsis:
+		return "..."
+	}
+
+	return "?"
+}
+
+func join(types []*Type) string {
+	var s []string
+	for _, t := range types {
+		s = append(s, t.String())
+	}
+
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,126 @@
+package pytypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/protocol"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+func node(typ, role, token string, children ...*uast.Node) *uast.Node {
+	return &uast.Node{
+		InternalType: typ,
+		Properties:   map[string]string{uast.InternalRoleKey: role},
+		Token:        token,
+		Children:     children,
+	}
+}
+
+func name(role, id string) *uast.Node {
+	return node("Name", role, id)
+}
+
+// sub returns the node of base[args].
+func sub(role string, base *uast.Node, args ...*uast.Node) *uast.Node {
+	base.Properties[uast.InternalRoleKey] = "value"
+	value := args[0]
+	if len(args) > 1 {
+		value = node("Tuple", "value", "", args...)
+	}
+
+	value.Properties[uast.InternalRoleKey] = "value"
+	return node("Subscript", role, "", base, node("Index", "slice", "", value))
+}
+
+func elts(n *uast.Node) *uast.Node {
+	n.Properties[uast.InternalRoleKey] = "elts"
+	return n
+}
+
+func TestParse(t *testing.T) {
+	require := require.New(t)
+
+	typing := func(id string) *uast.Node {
+		return node("Attribute", "", id, name("value", "typing"))
+	}
+
+	cases := []struct {
+		n        *uast.Node
+		kind     Kind
+		expected string
+	}{{
+		name("", "int"), Name, "int",
+	}, {
+		sub("", typing("Dict"), elts(name("", "str")), elts(name("", "int"))),
+		Generic, "typing.Dict[str, int]",
+	}, {
+		sub("", name("", "Optional"), sub("", name("", "List"), name("", "int"))),
+		Union, "List[int] | None",
+	}, {
+		sub("", typing("Union"), elts(name("", "int")), elts(node("BinOp", "", "",
+			name("left", "str"), node("BitOr", "op", "|"), node("NoneLiteral", "right", "None")))),
+		Union, "int | str | None",
+	}, {
+		sub("", name("", "Callable"),
+			elts(node("List", "", "", elts(name("", "int")), elts(name("", "str")))),
+			elts(name("", "bool"))),
+		Callable, "Callable[[int, str], bool]",
+	}, {
+		sub("", name("", "Callable"), elts(node("Ellipsis", "", "...")), elts(name("", "Any"))),
+		Callable, "Callable[..., Any]",
+	}, {
+		sub("", name("", "Literal"), elts(node("Str", "", "r")), elts(node("Num", "", "1")),
+			elts(node("UnaryOp", "", "", node("USub", "op", "-"), node("Num", "operand", "2")))),
+		Literal, `Literal["r", 1, -2]`,
+	}, {
+		// forward reference parsed by the normalizer
+		node("Str", "", "Node", name("forward_reference", "Node")), Name, "Node",
+	}, {
+		node("Str", "", "Node", node("SameLineNoops", "noops_sameline", "# comment"),
+			name("forward_reference", "Node")), Name, "Node",
+	}, {
+		// strings that aren't valid types
+		node("Str", "", "1 +"), Unknown, "?",
+	}, {
+		node("Str", "", "x", name("value", "x")), Unknown, "?",
+	}, {
+		node("Call", "", ""), Unknown, "?",
+	}}
+
+	for _, c := range cases {
+		typ := Parse(c.n)
+		require.Equal(c.kind, typ.Kind, c.expected)
+		require.Equal(c.expected, typ.String())
+	}
+
+	require.True(Parse(cases[2].n).Optional())
+	require.False(Parse(cases[1].n).Optional())
+}
+
+func TestAnnotator(t *testing.T) {
+	require := require.New(t)
+
+	// def f(x: int) -> Optional[str]: y: List[int] = []
+	x := node("arg", "args", "x", name("annotation", "int"))
+	target := name("target", "y")
+	f := node("FunctionDef", "body", "f",
+		node("arguments", "args", "", x),
+		sub("returns", name("", "Optional"), name("", "str")),
+		node("FunctionDef.body", "", "",
+			node("AnnAssign", "body", "", sub("annotation", name("", "List"), name("", "int")), target)),
+	)
+
+	module := node("Module", "", "", f)
+	require.NoError(NewAnnotator().Do("", protocol.UTF8, module))
+
+	require.Equal("int", x.Properties[TypeKey])
+	require.Equal("str | None", f.Properties[ReturnTypeKey])
+	require.Equal("List[int]", target.Properties[TypeKey])
+
+	decls := Declarations(module)
+	require.Len(decls, 3)
+	require.Equal(Return, decls[0].Kind)
+	require.Equal(Parameter, decls[1].Kind)
+	require.Equal(Variable, decls[2].Kind)
+}
@@ -0,0 +1,469 @@
+package unparser
+
+import (
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// Precedence of the expressions, from the loosest binding to the tightest, as
+// in Python's ast.unparse. An expression is parenthesized when it's an operand
+// requiring a higher precedence than its own.
+const (
+	precNamedExpr = iota
+	precTuple
+	precYield
+	precTest
+	precOr
+	precAnd
+	precNot
+	precCmp
+	precBor
+	precBxor
+	precBand
+	precShift
+	precArith
+	precTerm
+	precFactor
+	precPower
+	precAwait
+	precAtom
+)
+
+type operator struct {
+	symbol string
+	prec   int
+}
+
+// binOps are the operators of BinOp and AugAssign.
+var binOps = map[string]operator{
+	"Add":      {"+", precArith},
+	"Sub":      {"-", precArith},
+	"Mult":     {"*", precTerm},
+	"MatMult":  {"@", precTerm},
+	"Div":      {"/", precTerm},
+	"FloorDiv": {"//", precTerm},
+	"Mod":      {"%", precTerm},
+	"Pow":      {"**", precPower},
+	"LShift":   {"<<", precShift},
+	"RShift":   {">>", precShift},
+	"BitOr":    {"|", precBor},
+	"BitXor":   {"^", precBxor},
+	"BitAnd":   {"&", precBand},
+}
+
+var unaryOps = map[string]operator{
+	"Not":    {"not ", precNot},
+	"Invert": {"~", precFactor},
+	"UAdd":   {"+", precFactor},
+	"USub":   {"-", precFactor},
+}
+
+var boolOps = map[string]operator{
+	"And": {" and ", precAnd},
+	"Or":  {" or ", precOr},
+}
+
+var cmpOps = map[string]string{
+	"Eq":    "==",
+	"NotEq": "!=",
+	"Lt":    "<",
+	"LtE":   "<=",
+	"Gt":    ">",
+	"GtE":   ">=",
+	"Is":    "is",
+	"IsNot": "is not",
+	"In":    "in",
+	"NotIn": "not in",
+}
+
+// conversions are the conversions of the formatted values by their code.
+var conversions = map[string]string{"115": "!s", "114": "!r", "97": "!a"}
+
+func opType(n *uast.Node) string {
+	if n == nil {
+		return ""
+	}
+
+	return n.InternalType
+}
+
+func paren(s string, prec, required int) string {
+	if prec < required {
+		return "(" + s + ")"
+	}
+
+	return s
+}
+
+// expr returns the source of an expression, parenthesized if its precedence is
+// lower than prec.
+func (p *printer) expr(n *uast.Node, prec int) string {
+	if n == nil {
+		return p.fail(n)
+	}
+
+	switch n.InternalType {
+	case "Name", "NameConstant", "BoolLiteral", "NoneLiteral":
+		return n.Token
+	case "Num":
+		return p.num(n, prec)
+	case "Str", "StringLiteral":
+		return quote(n.Token)
+	case "Bytes", "ByteLiteral":
+		return quoteBytes(n.Token, n.Properties["encoding"])
+	case "Constant":
+		return constant(n)
+	case "JoinedStr":
+		return p.joinedStr(n)
+	case "Ellipsis":
+		return "..."
+	case "Attribute":
+		value := child(n, "value")
+		s := p.expr(value, precAtom)
+		if value != nil && value.InternalType == "Num" {
+			// 1.real would be read as a float
+			s = "(" + s + ")"
+		}
+
+		return s + "." + n.Token
+	case "Subscript":
+		return p.expr(child(n, "value"), precAtom) + "[" + p.slice(child(n, "slice")) + "]"
+	case "Starred":
+		return "*" + p.expr(child(n, "value"), precBor)
+	case "List":
+		return "[" + p.exprs(list(n, "elts"), precTest) + "]"
+	case "Tuple":
+		return "(" + p.tuple(list(n, "elts")) + ")"
+	case "Set":
+		elts := list(n, "elts")
+		if len(elts) == 0 {
+			return "{*()}"
+		}
+
+		return "{" + p.exprs(elts, precTest) + "}"
+	case "Dict":
+		return p.dict(n)
+	case "ListComp":
+		return "[" + p.expr(child(n, "elt"), precTest) + p.comprehensions(n) + "]"
+	case "SetComp":
+		return "{" + p.expr(child(n, "elt"), precTest) + p.comprehensions(n) + "}"
+	case "GeneratorExp":
+		return "(" + p.expr(child(n, "elt"), precTest) + p.comprehensions(n) + ")"
+	case "DictComp":
+		return "{" + p.expr(child(n, "key"), precTest) + ": " + p.expr(child(n, "value"), precTest) +
+			p.comprehensions(n) + "}"
+	case "Call":
+		return p.call(n)
+	case "Repr":
+		return "repr(" + p.expr(child(n, "value"), precTest) + ")"
+	case "BinOp":
+		op, ok := binOps[opType(child(n, "op"))]
+		if !ok {
+			return p.fail(child(n, "op"))
+		}
+
+		left, right := op.prec, op.prec+1
+		if op.prec == precPower {
+			left, right = right, left
+		}
+
+		s := p.expr(child(n, "left"), left) + " " + op.symbol + " " + p.expr(child(n, "right"), right)
+		return paren(s, op.prec, prec)
+	case "UnaryOp":
+		op, ok := unaryOps[opType(child(n, "op"))]
+		if !ok {
+			return p.fail(child(n, "op"))
+		}
+
+		return paren(op.symbol+p.expr(child(n, "operand"), op.prec), op.prec, prec)
+	case "BoolOp":
+		op, ok := boolOps[opType(child(n, "op"))]
+		if !ok {
+			return p.fail(child(n, "op"))
+		}
+
+		var values []string
+		for _, v := range list(n, "values") {
+			values = append(values, p.expr(v, op.prec+1))
+		}
+
+		return paren(strings.Join(values, op.symbol), op.prec, prec)
+	case "Compare":
+		s := p.expr(child(n, "left"), precCmp+1)
+		comparators := list(n, "comparators")
+		for i, op := range list(n, "ops") {
+			sym, ok := cmpOps[op.InternalType]
+			if !ok || i >= len(comparators) {
+				return p.fail(op)
+			}
+
+			s += " " + sym + " " + p.expr(comparators[i], precCmp+1)
+		}
+
+		return paren(s, precCmp, prec)
+	case "IfExp":
+		s := p.expr(child(n, "body"), precTest+1) + " if " + p.expr(child(n, "test"), precTest+1) +
+			" else " + p.expr(child(n, "orelse"), precTest)
+		return paren(s, precTest, prec)
+	case "Lambda":
+		s := "lambda"
+		if args := p.arguments(child(n, "args"), false); args != "" {
+			s += " " + args
+		}
+
+		return paren(s+": "+p.expr(child(n, "body"), precTest), precTest, prec)
+	case "NamedExpr":
+		s := p.expr(child(n, "target"), precAtom) + " := " + p.expr(child(n, "value"), precAtom)
+		return paren(s, precNamedExpr, prec)
+	case "Await":
+		return paren("await "+p.expr(child(n, "value"), precAtom), precAwait, prec)
+	case "Yield":
+		s := "yield"
+		if v := child(n, "value"); v != nil {
+			s += " " + p.expr(v, precTest)
+		}
+
+		return paren(s, precYield, prec)
+	case "YieldFrom":
+		return paren("yield from "+p.expr(child(n, "value"), precTest), precYield, prec)
+	}
+
+	return p.fail(n)
+}
+
+func (p *printer) exprs(nodes []*uast.Node, prec int) string {
+	var s []string
+	for _, n := range nodes {
+		s = append(s, p.expr(n, prec))
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// tuple returns the elements of a tuple, with a trailing comma if it has only
+// one.
+func (p *printer) tuple(elts []*uast.Node) string {
+	s := p.exprs(elts, precTest)
+	if len(elts) == 1 {
+		s += ","
+	}
+
+	return s
+}
+
+// num returns a number: the token, or the real and imaginary parts of the
+// complex numbers.
+func (p *printer) num(n *uast.Node, prec int) string {
+	s := n.Token
+	if c := child(n, "n"); c != nil {
+		s = c.Properties["imag"] + "j"
+		if r := c.Properties["real"]; r != "" && r != "0" {
+			return paren(r+" + "+s, precArith, prec)
+		}
+	}
+
+	if strings.HasPrefix(s, "-") {
+		// negative numbers of Python 2
+		return paren(s, precFactor, prec)
+	}
+
+	return s
+}
+
+// slice returns the slice of a Subscript.
+func (p *printer) slice(n *uast.Node) string {
+	if n == nil {
+		return p.fail(n)
+	}
+
+	switch n.InternalType {
+	case "Index":
+		return p.slice(child(n, "value"))
+	case "Slice":
+		var s string
+		if l := child(n, "lower"); l != nil {
+			s = p.expr(l, precTest)
+		}
+
+		s += ":"
+		if u := child(n, "upper"); u != nil {
+			s += p.expr(u, precTest)
+		}
+
+		if st := child(n, "step"); st != nil {
+			s += ":" + p.expr(st, precTest)
+		}
+
+		return s
+	case "ExtSlice", "Tuple":
+		field := "elts"
+		if n.InternalType == "ExtSlice" {
+			field = "dims"
+		}
+
+		elts := list(n, field)
+		if len(elts) == 0 {
+			return "()"
+		}
+
+		var s []string
+		for _, e := range elts {
+			s = append(s, p.slice(e))
+		}
+
+		if len(s) == 1 {
+			return s[0] + ","
+		}
+
+		return strings.Join(s, ", ")
+	}
+
+	return p.expr(n, precTest)
+}
+
+func (p *printer) dict(n *uast.Node) string {
+	keys, values := list(n, "keys"), list(n, "values")
+	if len(keys) != len(values) {
+		return p.fail(n)
+	}
+
+	var items []string
+	for i, k := range keys {
+		// **unpacking
+		if isMissing(k) {
+			items = append(items, "**"+p.expr(values[i], precBor))
+			continue
+		}
+
+		items = append(items, p.expr(k, precTest)+": "+p.expr(values[i], precTest))
+	}
+
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+func (p *printer) comprehensions(n *uast.Node) string {
+	var s string
+	for _, c := range list(n, "generators") {
+		if c.Properties["is_async"] == "1" {
+			s += " async"
+		}
+
+		s += " for " + p.expr(child(c, "target"), precTest) + " in " + p.expr(child(c, "iter"), precTest+1)
+		for _, cond := range list(c, "ifs") {
+			s += " if " + p.expr(cond, precTest+1)
+		}
+	}
+
+	return s
+}
+
+func (p *printer) call(n *uast.Node) string {
+	var args []string
+	for _, a := range list(n, "args") {
+		args = append(args, p.expr(a, precTest))
+	}
+
+	for _, k := range list(n, "keywords") {
+		args = append(args, p.keyword(k))
+	}
+
+	// Python 2
+	if s := child(n, "starargs"); s != nil {
+		args = append(args, "*"+p.expr(s, precBor))
+	}
+
+	if k := child(n, "kwargs"); k != nil {
+		args = append(args, "**"+p.expr(k, precBor))
+	}
+
+	return p.expr(child(n, "func"), precAtom) + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (p *printer) keyword(n *uast.Node) string {
+	if n.Token == "" {
+		return "**" + p.expr(child(n, "value"), precBor)
+	}
+
+	return n.Token + "=" + p.expr(child(n, "value"), precTest)
+}
+
+// arguments returns the arguments of a function or lambda, with their
+// annotations if requested.
+func (p *printer) arguments(n *uast.Node, annotations bool) string {
+	if n == nil {
+		return ""
+	}
+
+	var s []string
+	arg := func(a *uast.Node, prefix string, def *uast.Node) {
+		// Python 2 has Name and Tuple nodes as arguments
+		text := a.Token
+		if a.InternalType == "Tuple" {
+			text = p.expr(a, precTest)
+		}
+
+		ann := child(a, "annotation")
+		if annotations && ann != nil && a.InternalType == "arg" {
+			text += ": " + p.expr(ann, precTest)
+		}
+
+		if def != nil {
+			if annotations && ann != nil {
+				text += " = " + p.expr(def, precTest)
+			} else {
+				text += "=" + p.expr(def, precTest)
+			}
+		}
+
+		s = append(s, prefix+text)
+	}
+
+	posonly, args := list(n, "posonlyargs"), list(n, "args")
+	defaults := list(n, "defaults")
+	first := len(posonly) + len(args) - len(defaults)
+	for i, a := range append(posonly, args...) {
+		var def *uast.Node
+		if i >= first {
+			def = defaults[i-first]
+		}
+
+		arg(a, "", def)
+		if i == len(posonly)-1 {
+			s = append(s, "/")
+		}
+	}
+
+	kwonly, kwDefaults := list(n, "kwonlyargs"), list(n, "kw_defaults")
+	if v := child(n, "vararg"); v != nil {
+		arg(v, "*", nil)
+	} else if v := n.Properties["vararg"]; v != "" {
+		s = append(s, "*"+v)
+	} else if len(kwonly) > 0 {
+		s = append(s, "*")
+	}
+
+	for i, a := range kwonly {
+		var def *uast.Node
+		if len(kwDefaults) == len(kwonly) && !isMissing(kwDefaults[i]) {
+			def = kwDefaults[i]
+		}
+
+		arg(a, "", def)
+	}
+
+	if k := child(n, "kwarg"); k != nil {
+		arg(k, "**", nil)
+	} else if k := n.Properties["kwarg"]; k != "" {
+		s = append(s, "**"+k)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// isMissing returns true for the NoneLiteral nodes without position that the
+// native driver puts in the lists instead of the missing values, as the keys
+// of **unpacking in a Dict.
+func isMissing(n *uast.Node) bool {
+	return n.InternalType == "NoneLiteral" && n.StartPosition == nil
+}
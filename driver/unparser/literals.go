@@ -0,0 +1,169 @@
+package unparser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+// quote returns a string literal of s, quoted like Python's repr: with single
+// quotes, unless s has single quotes and no double ones.
+func quote(s string) string {
+	q := quoteFor(s)
+	return q + escape(s, q[0]) + q
+}
+
+func quoteFor(s string) string {
+	if strings.Contains(s, "'") && !strings.Contains(s, `"`) {
+		return `"`
+	}
+
+	return "'"
+}
+
+// escape escapes the backslashes, the quote, the control characters and the
+// non printable characters of s.
+func escape(s string, quote byte) string {
+	var b bytes.Buffer
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == rune(quote):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f || r >= 0x80 && r < 0xa0:
+			fmt.Fprintf(&b, `\x%02x`, r)
+		case unicode.IsPrint(r):
+			b.WriteRune(r)
+		case r <= 0xffff:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			fmt.Fprintf(&b, `\U%08x`, r)
+		}
+	}
+
+	return b.String()
+}
+
+// quoteBytes returns a bytes literal of the s of a Bytes node, encoded by the
+// native driver as "utf8" or "base64".
+func quoteBytes(s, encoding string) string {
+	data := []byte(s)
+	if encoding == "base64" {
+		if decoded, err := base64.StdEncoding.DecodeString(s); err == nil {
+			data = decoded
+		}
+	}
+
+	q := quoteFor(string(data))
+	var b bytes.Buffer
+	b.WriteString("b" + q)
+	for _, c := range data {
+		switch {
+		case c == '\\' || c == q[0]:
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	b.WriteString(q)
+	return b.String()
+}
+
+// constant returns the literal of a Constant node: its token, quoted unless
+// it's a number or a singleton.
+func constant(n *uast.Node) string {
+	switch s := n.Token; s {
+	case "True", "False", "None", "...":
+		return s
+	default:
+		if _, err := strconv.ParseFloat(strings.TrimSuffix(s, "j"), 64); err == nil {
+			return s
+		}
+
+		return quote(s)
+	}
+}
+
+// joinedStr returns the f-string of a JoinedStr, with the first quotes not
+// used by the expressions inside it, since they can't be escaped there.
+func (p *printer) joinedStr(n *uast.Node) string {
+	var exprs []string
+	p.formattedValues(n, &exprs)
+	for _, q := range []string{"'", `"`, "'''", `"""`} {
+		used := false
+		for _, e := range exprs {
+			used = used || strings.Contains(e, q)
+		}
+
+		if !used {
+			return "f" + q + p.fstring(n, q[0]) + q
+		}
+	}
+
+	return p.fail(n)
+}
+
+// formattedValues adds the source of the expressions of a JoinedStr to exprs.
+func (p *printer) formattedValues(n *uast.Node, exprs *[]string) {
+	for _, v := range list(n, "values") {
+		if v.InternalType != "FormattedValue" {
+			continue
+		}
+
+		*exprs = append(*exprs, p.expr(child(v, "value"), precTest+1))
+		if spec := child(v, "format_spec"); spec != nil {
+			p.formattedValues(spec, exprs)
+		}
+	}
+}
+
+// fstring returns the content of the f-string of a JoinedStr.
+func (p *printer) fstring(n *uast.Node, quote byte) string {
+	var b bytes.Buffer
+	for _, v := range list(n, "values") {
+		switch v.InternalType {
+		case "Str", "StringLiteral", "Constant":
+			s := escape(v.Token, quote)
+			s = strings.Replace(s, "{", "{{", -1)
+			b.WriteString(strings.Replace(s, "}", "}}", -1))
+		case "FormattedValue":
+			e := p.expr(child(v, "value"), precTest+1)
+			if strings.HasPrefix(e, "{") {
+				// {{ would be an escaped brace
+				e = " " + e
+			}
+
+			b.WriteString("{" + e + conversions[v.Properties["conversion"]])
+			if spec := child(v, "format_spec"); spec != nil {
+				b.WriteString(":" + p.fstring(spec, quote))
+			}
+
+			b.WriteString("}")
+		default:
+			p.fail(v)
+		}
+	}
+
+	return b.String()
+}
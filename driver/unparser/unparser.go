@@ -0,0 +1,570 @@
+// Package unparser generates Python 3 source code from the UAST of the
+// normalizer, like Python's ast.unparse: parsing the code again gives the same
+// UAST, except for the positions and the comments, which are not generated.
+//
+// The nodes of the Python 2 grammar are written as their Python 3 equivalent:
+// the print and exec statements and the backquotes as calls to the builtins,
+// TryExcept and TryFinally as try statements.
+package unparser
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/bblfsh/python-driver/driver/normalizer"
+
+	"gopkg.in/bblfsh/sdk.v1/uast"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrUnsupported is returned for the nodes that can't be written as Python
+// code.
+var ErrUnsupported = errors.NewKind("unsupported node: %s")
+
+// indent is the indentation of a block.
+const indent = "    "
+
+// Unparse returns the Python 3 source of a UAST: a module, a statement or an
+// expression. The statements end with a line break.
+func Unparse(n *uast.Node) (string, error) {
+	p := &printer{}
+	if !p.writeStmt(n) {
+		p.buf.WriteString(p.expr(n, precTest))
+	}
+
+	if p.err != nil {
+		return "", p.err
+	}
+
+	return p.buf.String(), nil
+}
+
+// printer writes the source of the statements.
+type printer struct {
+	buf   bytes.Buffer
+	depth int
+	// err is the first error found.
+	err error
+}
+
+// fail records an unsupported node, returning the empty string as its source.
+func (p *printer) fail(n *uast.Node) string {
+	if p.err == nil {
+		if n == nil {
+			p.err = ErrUnsupported.New("missing node")
+		} else {
+			p.err = ErrUnsupported.New(n.InternalType)
+		}
+	}
+
+	return ""
+}
+
+func (p *printer) line(s string) {
+	p.buf.WriteString(strings.Repeat(indent, p.depth))
+	p.buf.WriteString(s)
+	p.buf.WriteByte('\n')
+}
+
+// block writes a compound statement clause.
+func (p *printer) block(header string, body []*uast.Node) {
+	p.line(header + ":")
+	p.depth++
+	if len(body) == 0 {
+		p.line("pass")
+	}
+
+	for _, s := range body {
+		p.stmt(s)
+	}
+
+	p.depth--
+}
+
+func (p *printer) stmt(n *uast.Node) {
+	if !p.writeStmt(n) {
+		p.fail(n)
+	}
+}
+
+// writeStmt writes a statement, returning false if n isn't one.
+func (p *printer) writeStmt(n *uast.Node) bool {
+	switch n.InternalType {
+	case "Module", "Interactive", "Suite":
+		p.body(n)
+	case "Expression":
+		p.line(p.expr(child(n, "body"), precTest))
+	case "FunctionDef", "AsyncFunctionDef":
+		p.function(n)
+	case "ClassDef":
+		p.class(n)
+	case "Return":
+		p.returnStmt(n)
+	case "Delete":
+		p.line("del " + p.exprs(list(n, "targets"), precTest))
+	case "Assign":
+		p.assign(n)
+	case "AugAssign":
+		p.augAssign(n)
+	case "AnnAssign":
+		p.annAssign(n)
+	case "For", "AsyncFor":
+		p.forStmt(n)
+	case "While":
+		p.block("while "+p.expr(child(n, "test"), precTest), list(n, "body"))
+		p.orElse(n)
+	case "If":
+		p.ifStmt(n, "if")
+	case "With", "AsyncWith":
+		p.with(n)
+	case "Match":
+		p.match(n)
+	case "Raise":
+		p.raise(n)
+	case "Try", "TryStar", "TryExcept", "TryFinally":
+		p.try(n)
+	case "Assert":
+		p.assert(n)
+	case "Import":
+		p.line("import " + p.aliases(n))
+	case "ImportFrom":
+		p.importFrom(n)
+	case "Global":
+		p.names("global", n)
+	case "Nonlocal":
+		p.names("nonlocal", n)
+	case "Expr":
+		p.line(p.expr(child(n, "value"), precYield))
+	case "Pass":
+		p.line("pass")
+	case "Break":
+		p.line("break")
+	case "Continue":
+		p.line("continue")
+	case "Print":
+		p.print(n)
+	case "Exec":
+		p.exec(n)
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (p *printer) body(n *uast.Node) {
+	for _, s := range list(n, "body") {
+		p.stmt(s)
+	}
+}
+
+func (p *printer) decorators(n *uast.Node) {
+	for _, d := range list(n, "decorator_list") {
+		p.line("@" + p.expr(d, precAtom))
+	}
+}
+
+func (p *printer) function(n *uast.Node) {
+	p.decorators(n)
+	header := "def " + n.Token + "(" + p.arguments(child(n, "args"), true) + ")"
+	if n.InternalType == "AsyncFunctionDef" {
+		header = "async " + header
+	}
+
+	if r := child(n, "returns"); r != nil {
+		header += " -> " + p.expr(r, precTest)
+	}
+
+	p.block(header, list(n, "body"))
+}
+
+func (p *printer) class(n *uast.Node) {
+	p.decorators(n)
+	var args []string
+	for _, b := range list(n, "bases") {
+		args = append(args, p.expr(b, precTest))
+	}
+
+	for _, k := range list(n, "keywords") {
+		args = append(args, p.keyword(k))
+	}
+
+	header := "class " + n.Token
+	if len(args) > 0 {
+		header += "(" + strings.Join(args, ", ") + ")"
+	}
+
+	p.block(header, list(n, "body"))
+}
+
+func (p *printer) returnStmt(n *uast.Node) {
+	if v := child(n, "value"); v != nil {
+		p.line("return " + p.expr(v, precTest))
+		return
+	}
+
+	p.line("return")
+}
+
+func (p *printer) assign(n *uast.Node) {
+	var s string
+	for _, t := range list(n, "targets") {
+		s += p.expr(t, precTest) + " = "
+	}
+
+	p.line(s + p.expr(child(n, "value"), precTest))
+}
+
+func (p *printer) augAssign(n *uast.Node) {
+	op, ok := binOps[opType(child(n, "op"))]
+	if !ok {
+		p.fail(child(n, "op"))
+		return
+	}
+
+	p.line(p.expr(child(n, "target"), precTest) + " " + op.symbol + "= " +
+		p.expr(child(n, "value"), precTest))
+}
+
+func (p *printer) annAssign(n *uast.Node) {
+	t := child(n, "target")
+	target := p.expr(t, precTest)
+	if n.Properties["simple"] == "0" && t != nil && t.InternalType == "Name" {
+		target = "(" + target + ")"
+	}
+
+	s := target + ": " + p.expr(child(n, "annotation"), precTest)
+	if v := child(n, "value"); v != nil {
+		s += " = " + p.expr(v, precTest)
+	}
+
+	p.line(s)
+}
+
+func (p *printer) forStmt(n *uast.Node) {
+	header := "for " + p.expr(child(n, "target"), precTest) + " in " + p.expr(child(n, "iter"), precTest)
+	if n.InternalType == "AsyncFor" {
+		header = "async " + header
+	}
+
+	p.block(header, list(n, "body"))
+	p.orElse(n)
+}
+
+func (p *printer) orElse(n *uast.Node) {
+	if orelse := list(n, "orelse"); len(orelse) > 0 {
+		p.block("else", orelse)
+	}
+}
+
+func (p *printer) ifStmt(n *uast.Node, keyword string) {
+	p.block(keyword+" "+p.expr(child(n, "test"), precTest), list(n, "body"))
+	orelse := list(n, "orelse")
+	switch {
+	case len(orelse) == 1 && orelse[0].InternalType == "If":
+		p.ifStmt(orelse[0], "elif")
+	case len(orelse) > 0:
+		p.block("else", orelse)
+	}
+}
+
+func (p *printer) with(n *uast.Node) {
+	items := list(n, "items")
+	if len(items) == 0 {
+		// Python 2 has a With node by item
+		items = []*uast.Node{n}
+	}
+
+	var s []string
+	for _, item := range items {
+		w := p.expr(child(item, "context_expr"), precTest)
+		if v := child(item, "optional_vars"); v != nil {
+			w += " as " + p.expr(v, precTest)
+		}
+
+		s = append(s, w)
+	}
+
+	header := "with " + strings.Join(s, ", ")
+	if n.InternalType == "AsyncWith" {
+		header = "async " + header
+	}
+
+	p.block(header, list(n, "body"))
+}
+
+func (p *printer) raise(n *uast.Node) {
+	s := "raise"
+	switch {
+	case child(n, "exc") != nil:
+		s += " " + p.expr(child(n, "exc"), precTest)
+		if c := child(n, "cause"); c != nil {
+			s += " from " + p.expr(c, precTest)
+		}
+	case child(n, "type") != nil:
+		// Python 2: raise type, inst, tback
+		s += " " + p.expr(child(n, "type"), precAtom)
+		if inst := child(n, "inst"); inst != nil {
+			s += "(" + p.expr(inst, precTest) + ")"
+		}
+
+		if tb := child(n, "tback"); tb != nil {
+			s += ".with_traceback(" + p.expr(tb, precTest) + ")"
+		}
+	}
+
+	p.line(s)
+}
+
+func (p *printer) try(n *uast.Node) {
+	p.block("try", list(n, "body"))
+	except := "except"
+	if n.InternalType == "TryStar" {
+		except = "except*"
+	}
+
+	for _, h := range list(n, "handlers") {
+		header := except
+		if t := child(h, "type"); t != nil {
+			header += " " + p.expr(t, precTest)
+		}
+
+		if name := handlerName(h); name != "" {
+			header += " as " + name
+		}
+
+		p.block(header, list(h, "body"))
+	}
+
+	p.orElse(n)
+	if final := list(n, "finalbody"); len(final) > 0 {
+		p.block("finally", final)
+	}
+}
+
+// handlerName returns the name of an ExceptHandler, a Name node in Python 2.
+func handlerName(h *uast.Node) string {
+	if name := h.Properties["ExceptHandler.name"]; name != "" {
+		return name
+	}
+
+	if name := child(h, "name"); name != nil {
+		return name.Token
+	}
+
+	return ""
+}
+
+func (p *printer) assert(n *uast.Node) {
+	s := "assert " + p.expr(child(n, "test"), precTest)
+	if msg := child(n, "msg"); msg != nil {
+		s += ", " + p.expr(msg, precTest)
+	}
+
+	p.line(s)
+}
+
+func (p *printer) aliases(n *uast.Node) string {
+	var names []string
+	for _, a := range list(n, "names") {
+		name := a.Token
+		if as := a.Properties["alias.asname"]; as != "" {
+			name += " as " + as
+		}
+
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (p *printer) importFrom(n *uast.Node) {
+	level, _ := strconv.Atoi(n.Properties["level"])
+	module := strings.Repeat(".", level) + n.Properties["ImportFrom.module"]
+	p.line("from " + module + " import " + p.aliases(n))
+}
+
+func (p *printer) names(keyword string, n *uast.Node) {
+	var names []string
+	for _, name := range list(n, "names") {
+		names = append(names, name.Token)
+	}
+
+	p.line(keyword + " " + strings.Join(names, ", "))
+}
+
+// print writes the Python 2 print statement as a call to the print function.
+func (p *printer) print(n *uast.Node) {
+	var args []string
+	for _, v := range list(n, "values") {
+		args = append(args, p.expr(v, precTest))
+	}
+
+	if n.Properties["nl"] == "false" {
+		args = append(args, "end=' '")
+	}
+
+	if dest := child(n, "dest"); dest != nil {
+		args = append(args, "file="+p.expr(dest, precTest))
+	}
+
+	p.line("print(" + strings.Join(args, ", ") + ")")
+}
+
+// exec writes the Python 2 exec statement as a call to the exec function.
+func (p *printer) exec(n *uast.Node) {
+	args := []string{p.expr(child(n, "body"), precTest)}
+	for _, role := range []string{"globals", "locals"} {
+		if c := child(n, role); c != nil {
+			args = append(args, p.expr(c, precTest))
+		}
+	}
+
+	p.line("exec(" + strings.Join(args, ", ") + ")")
+}
+
+func (p *printer) match(n *uast.Node) {
+	p.line("match " + p.expr(child(n, "subject"), precTest) + ":")
+	p.depth++
+	for _, c := range list(n, "cases") {
+		header := "case " + p.pattern(child(c, "pattern"))
+		if g := child(c, "guard"); g != nil {
+			header += " if " + p.expr(g, precTest)
+		}
+
+		p.block(header, list(c, "body"))
+	}
+
+	p.depth--
+}
+
+// pattern returns the source of a pattern of a match statement.
+func (p *printer) pattern(n *uast.Node) string {
+	if n == nil {
+		return p.fail(n)
+	}
+
+	switch n.InternalType {
+	case "MatchValue", "MatchSingleton":
+		if v := child(n, "value"); v != nil {
+			return p.expr(v, precTest)
+		}
+
+		return n.Token
+	case "MatchSequence":
+		return "[" + p.patterns(list(n, "patterns")) + "]"
+	case "MatchStar":
+		if n.Token == "" {
+			return "*_"
+		}
+
+		return "*" + n.Token
+	case "MatchMapping":
+		var items []string
+		patterns := list(n, "patterns")
+		for i, k := range list(n, "keys") {
+			if i < len(patterns) {
+				items = append(items, p.expr(k, precTest)+": "+p.pattern(patterns[i]))
+			}
+		}
+
+		if rest := n.Properties["rest"]; rest != "" {
+			items = append(items, "**"+rest)
+		}
+
+		return "{" + strings.Join(items, ", ") + "}"
+	case "MatchClass":
+		args := []string{p.patterns(list(n, "patterns"))}
+		if args[0] == "" {
+			args = nil
+		}
+
+		patterns := list(n, "kwd_patterns")
+		for i, attr := range list(n, "kwd_attrs") {
+			if i < len(patterns) {
+				args = append(args, attr.Token+"="+p.pattern(patterns[i]))
+			}
+		}
+
+		return p.expr(child(n, "cls"), precAtom) + "(" + strings.Join(args, ", ") + ")"
+	case "MatchAs":
+		name := n.Token
+		if name == "" {
+			name = "_"
+		}
+
+		if pat := child(n, "pattern"); pat != nil {
+			return p.pattern(pat) + " as " + name
+		}
+
+		return name
+	case "MatchOr":
+		var alts []string
+		for _, alt := range list(n, "patterns") {
+			alts = append(alts, p.pattern(alt))
+		}
+
+		return strings.Join(alts, " | ")
+	}
+
+	return p.fail(n)
+}
+
+func (p *printer) patterns(nodes []*uast.Node) string {
+	var s []string
+	for _, n := range nodes {
+		s = append(s, p.pattern(n))
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// noops are the node types of the comments and blank lines.
+var noops = map[string]bool{
+	"NoopLine":       true,
+	"PreviousNoops":  true,
+	"SameLineNoops":  true,
+	"RemainderNoops": true,
+}
+
+// skipped returns true for the nodes not generated: the comments and the
+// annotations of the type comments.
+func skipped(n *uast.Node) bool {
+	return noops[n.InternalType] || n.Properties[normalizer.TypeCommentKey] == "true"
+}
+
+// child returns the child of n for a single field.
+func child(n *uast.Node, field string) *uast.Node {
+	for _, c := range n.Children {
+		if c.Properties[uast.InternalRoleKey] == field && !skipped(c) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// list returns the nodes of a list field, either promoted to its own node
+// (as FunctionDef.body) or as children of n.
+func list(n *uast.Node, field string) []*uast.Node {
+	promoted := n.InternalType + "." + field
+	var nodes []*uast.Node
+	for _, c := range n.Children {
+		switch {
+		case c.InternalType == promoted:
+			nodes = nil
+			for _, e := range c.Children {
+				if !skipped(e) {
+					nodes = append(nodes, e)
+				}
+			}
+
+			return nodes
+		case c.Properties[uast.InternalRoleKey] == field && !skipped(c):
+			nodes = append(nodes, c)
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,152 @@
+package unparser
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/bblfsh/python-driver/driver/parser"
+	"github.com/bblfsh/python-driver/driver/parser/fakenative"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/bblfsh/sdk.v1/uast"
+)
+
+var fixtureDir = filepath.Join("..", "..", "fixtures")
+
+func TestMain(m *testing.M) {
+	fakenative.Main(fixtureDir)
+	code := m.Run()
+	parser.Close()
+	os.Exit(code)
+}
+
+func fixtures(t *testing.T) []string {
+	paths, err := filepath.Glob(filepath.Join(fixtureDir, "*.py"))
+	require.NoError(t, err)
+	return paths
+}
+
+func parse(t *testing.T, src []byte, native string) *uast.Node {
+	n, err := parser.Parse(context.Background(), src, &parser.Options{Native: native})
+	require.NoError(t, err)
+	return n
+}
+
+func parseFixture(t *testing.T, name string) *uast.Node {
+	src, err := ioutil.ReadFile(filepath.Join(fixtureDir, name))
+	require.NoError(t, err)
+	return parse(t, src, os.Args[0])
+}
+
+func TestUnparse(t *testing.T) {
+	require := require.New(t)
+
+	for name, expected := range map[string]string{
+		"u2_func_params_default.py": "def testfn1(a, b=1):\n    pass\n",
+		"print.py":                  "print(1)\nprint(2)\n",
+		"except.py": "try:\n    a = 1\n    raise Exception('gogogo')\n" +
+			"except SomeException as e:\n    print('someexception catched')\n" +
+			"except:\n    print('ayyyy')\n" +
+			"finally:\n    print('here we are')\n",
+	} {
+		code, err := Unparse(parseFixture(t, name))
+		require.NoError(err, name)
+		require.Equal(expected, code, name)
+	}
+}
+
+func TestUnparseFString(t *testing.T) {
+	require := require.New(t)
+
+	code, err := Unparse(parseFixture(t, "string_fstring.py"))
+	require.NoError(err)
+	require.Contains(code, "f'Another with {b:{2}.{3}} width and precission float indicator'\n")
+	require.Contains(code, "f\"Another with {'pok'.upper()} an embedded expression\"\n")
+}
+
+func TestUnparseExpression(t *testing.T) {
+	require := require.New(t)
+
+	n := parseFixture(t, "aritmeticops.py")
+	expr := child(n.Children[0], "value")
+	require.NotNil(expr)
+
+	code, err := Unparse(expr)
+	require.NoError(err)
+	require.Equal("1 + 2", code)
+}
+
+func TestUnparseUnsupported(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Unparse(&uast.Node{InternalType: "Unknown"})
+	require.True(ErrUnsupported.Is(err))
+}
+
+func TestEscape(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(`'a\'b"c'`, quote(`a'b"c`))
+	require.Equal(`"it's"`, quote("it's"))
+	require.Equal(`'\n\t\x00\x7f\\é\u200b'`, quote("\n\t\x00\x7f\\é\u200b"))
+	require.Equal(`b'\xff\n'`, quoteBytes("/wo=", "base64"))
+}
+
+func TestUnparseFixtures(t *testing.T) {
+	for _, path := range fixtures(t) {
+		src, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		if len(src) == 0 {
+			continue
+		}
+
+		_, err = Unparse(parse(t, src, os.Args[0]))
+		require.NoError(t, err, path)
+	}
+}
+
+// TestRoundTrip parses the unparsed fixtures again, which needs the native
+// driver.
+func TestRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath(parser.DefaultNative); err != nil {
+		t.Skip("the native driver is not installed")
+	}
+
+	require := require.New(t)
+	for _, path := range fixtures(t) {
+		src, err := ioutil.ReadFile(path)
+		require.NoError(err)
+		if len(src) == 0 {
+			continue
+		}
+
+		n := parse(t, src, parser.DefaultNative)
+		if n.Properties["pythonVersion"] == "2" {
+			continue
+		}
+
+		code, err := Unparse(n)
+		require.NoError(err, path)
+
+		again := parse(t, []byte(code), parser.DefaultNative)
+		require.Equal(strip(n), strip(again), path)
+	}
+}
+
+// strip returns a copy of a UAST without the positions and the nodes not
+// generated by Unparse.
+func strip(n *uast.Node) *uast.Node {
+	c := *n
+	c.StartPosition, c.EndPosition = nil, nil
+	c.Children = nil
+	for _, ch := range n.Children {
+		if !skipped(ch) {
+			c.Children = append(c.Children, strip(ch))
+		}
+	}
+
+	return &c
+}
@@ -1,5 +1,6 @@
 import abc
 import json
+import platform
 from pydetector import detector
 from traceback import format_exc
 from python_driver.version import __version__
@@ -102,6 +103,55 @@ class RequestProcessor(metaclass=abc.ABCMeta):
             response['filepath'] = filepath
         self._send_response(response)
 
+    @staticmethod
+    def _python_version(request: Request) -> int:
+        """
+        Return the Python version requested with the python_version field: 2, 3
+        or 0 to detect it ("auto", the default).
+        """
+        value = str(request.get('python_version', 'auto') or 'auto')
+        if value == 'auto':
+            return 0
+        if value in ('2', '3'):
+            return int(value)
+        raise Exception('Invalid python_version "%s": must be 2, 3 or auto' % value)
+
+    @staticmethod
+    def _ast_errors(codeinfo: Dict[str, Any], python_version: int) -> str:
+        """
+        Return the message with the errors produced trying to get the AST of a
+        Python version or, if 0, of both.
+        """
+        if python_version:
+            return ('Errors produced trying to get an AST for Python %d' % python_version +
+                    '\n------ Python%d errors:\n%s' % (python_version,
+                        codeinfo['py%d_ast_errors' % python_version]))
+
+        return ('Errors produced trying to get an AST for both Python versions' +
+                '\n------ Python2 errors:\n%s' % codeinfo['py2_ast_errors'] +
+                '\n------ Python3 errors:\n%s' % codeinfo['py3_ast_errors'])
+
+    @staticmethod
+    def _confidence(codeinfo: Dict[str, Any]) -> Dict[str, float]:
+        """
+        Return the confidence of the detector, from 0 to 1, on the code being of
+        each Python version: a version that doesn't parse has 0, otherwise it
+        comes from the scores of the version-specific syntax and modules.
+        """
+        py2ok = codeinfo['py2ast'] is not None
+        py3ok = codeinfo['py3ast'] is not None
+        if py2ok != py3ok:
+            return {'2': float(py2ok), '3': float(py3ok)}
+        if not py2ok:
+            return {'2': 0.0, '3': 0.0}
+
+        py2score = max(codeinfo['py2_score'], 0)
+        py3score = max(codeinfo['py3_score'], 0)
+        if py2score + py3score == 0:
+            return {'2': 0.5, '3': 0.5}
+        return {'2': py2score / (py2score + py3score),
+                '3': py3score / (py2score + py3score)}
+
     def process_request(self, request: RawRequest) -> None:
         """
         Main function doing the work of processing a single request. It will
@@ -114,6 +164,7 @@ class RequestProcessor(metaclass=abc.ABCMeta):
         """
         filepath    = ''
         ast         = None
+        metadata: Dict[str, Any] = {}
         self.errors = []
 
         try:
@@ -121,48 +172,66 @@ class RequestProcessor(metaclass=abc.ABCMeta):
             code = asstr(str_request.get('content', ''))
 
             if code:
+                python_version = self._python_version(str_request)
+                dual = bool(str_request.get('dual', False))
+
                 # We want the code detection to be fast and we prefer Python3 AST so using
                 # the stop_on_ok_ast will avoid running a Python2 subprocess to check the
-                # AST with Python2 if the Python3 version (which is a better AST anyway) worked
-                resdict  = detector.detect(codestr=code, stop_on_ok_ast=True)
+                # AST with Python2 if the Python3 version (which is a better AST anyway) worked.
+                # A requested Python2 or both ASTs need the Python2 check too.
+                stop_on_ok_ast = python_version != 2 and not dual
+                resdict  = detector.detect(codestr=code, stop_on_ok_ast=stop_on_ok_ast)
                 codeinfo = resdict['<code_string>']
-                version  = codeinfo['version']
-
-                if version in (3, 6) and codeinfo['py3ast']:
-                    orig_ast = codeinfo['py3ast']["PY3AST"]
-                elif version in (1, 2) and codeinfo['py2ast']:
-                    orig_ast = codeinfo['py2ast']["PY2AST"]
+                version  = python_version or codeinfo['version']
+
+                asts = {}
+                if codeinfo['py2ast'] and (dual or version in (1, 2)):
+                    asts[2] = codeinfo['py2ast']["PY2AST"]
+                if codeinfo['py3ast'] and (dual or version in (3, 6)):
+                    asts[3] = codeinfo['py3ast']["PY3AST"]
+
+                if not asts:
+                    raise Exception(self._ast_errors(codeinfo, python_version))
+
+                if dual:
+                    if version not in (2, 3) or version not in asts:
+                        version = 3 if 3 in asts else 2
+                    metadata['confidence'] = self._confidence(codeinfo)
+                    for v in (2, 3):
+                        if v not in asts:
+                            self.errors.append(self._ast_errors(codeinfo, v))
                 else:
-                    raise Exception(
-                        'Errors produced trying to get an AST for both Python versions' +
-                        '\n------ Python2 errors:\n%s' % codeinfo['py2_ast_errors'] +
-                        '\n------ Python3 errors:\n%s' % codeinfo['py3_ast_errors']
-                    )
-
-                if not orig_ast:
-                    raise Exception('Empty AST generated from non empty code')
-                ast = AstImprover(code, orig_ast).parse()
-                if not ast:
-                    raise Exception('Empty AST generated from non empty code')
+                    version = 3 if version in (3, 6) else 2
+
+                ast = {}
+                for v, orig_ast in asts.items():
+                    if not orig_ast:
+                        raise Exception('Empty AST generated from non empty code')
+                    improved = AstImprover(code, orig_ast).parse()
+                    if not improved:
+                        raise Exception('Empty AST generated from non empty code')
+                    ast["PY%dAST" % v] = improved
             else:
                 # Module with empty code (like __init__.py) return a module-only AST
                 # since this would still have semantic meaning for Python
-                ast = {
+                ast = {"PY3AST": {
                         "ast_type"   : "Module",
                         "lineno"     : 1,
                         "col_offset" : 1,
-                       }
+                       }}
                 version = 3
 
+            metadata.update({
+                'language'         : 'python',
+                'language_version' : version,
+                'driver'           : 'python23:%s' % __version__,
+                'native_version'   : platform.python_version(),
+            })
             response = Response({
                 'status'           : 'ok',
                 'errors'           : self.errors,
-                'ast'              : {"PY%dAST" % version: ast},
-                'metadata'         : {
-                    'language'         : 'python',
-                    'language_version' : version,
-                    'driver'           : 'python23:%s' % __version__,
-                }
+                'ast'              : ast,
+                'metadata'         : metadata,
             })
 
             if filepath:
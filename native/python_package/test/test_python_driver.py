@@ -1,6 +1,7 @@
 import io
 import json
 import os
+import platform
 import subprocess
 import sys
 import unittest
@@ -163,6 +164,48 @@ class Test10ProcessRequestFunc(TestPythonDriverBase):
         self.assertEqual(reply['status'], 'fatal')
         self.assertEqual(len(reply['errors']), 1)
 
+    def test_050_python_version(self) -> None:
+        for version in ('2', '3', 2, 3):
+            replies = self._send_receive(1, 'json', {'python_version': version})
+            self.assertEqual(len(replies), 1)
+            self._check_reply_dict(replies[0])
+            self.assertEqual(list(replies[0]['ast'].keys()), ['PY%sAST' % version])
+            self.assertEqual(str(replies[0]['metadata']['language_version']), str(version))
+
+    def test_060_python_version_error(self) -> None:
+        replies = self._send_receive(1, 'json', {'content': 'print "a"\n',
+                                                 'python_version': '3'})
+        self.assertEqual(len(replies), 1)
+        self._check_reply_dict(replies[0], has_errors=True)
+        self.assertIn('------ Python3 errors:', replies[0]['errors'][0])
+
+        replies = self._send_receive(1, 'json', {'python_version': '4'})
+        self._check_reply_dict(replies[0], has_errors=True)
+        self.assertIn('Invalid python_version', replies[0]['errors'][0])
+
+    def test_070_dual(self) -> None:
+        replies = self._send_receive(1, 'json', {'dual': True})
+        self.assertEqual(len(replies), 1)
+        self._check_reply_dict(replies[0])
+        self.assertEqual(sorted(replies[0]['ast'].keys()), ['PY2AST', 'PY3AST'])
+        confidence = replies[0]['metadata']['confidence']
+        self.assertEqual(sorted(confidence.keys()), ['2', '3'])
+        self.assertAlmostEqual(confidence['2'] + confidence['3'], 1.0)
+
+        replies = self._send_receive(1, 'json', {'content': 'print "a"\n', 'dual': True})
+        self._check_AST_dict(replies[0])
+        self.assertEqual(list(replies[0]['ast'].keys()), ['PY2AST'])
+        self.assertEqual(replies[0]['metadata']['language_version'], 2)
+        self.assertEqual(replies[0]['metadata']['confidence'], {'2': 1.0, '3': 0.0})
+        self.assertIn('------ Python3 errors:', replies[0]['errors'][0])
+
+    def test_080_versions(self) -> None:
+        replies = self._send_receive(1, 'json', {'content': ''})
+        self.assertEqual(len(replies), 1)
+        self.assertEqual(replies[0]['status'], 'ok')
+        self.assertEqual(replies[0]['metadata']['driver'], 'python23:%s' % __version__)
+        self.assertEqual(replies[0]['metadata']['native_version'], platform.python_version())
+
 
 class Test20ReqProcMethods(TestPythonDriverBase):
 